@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceAwsCognitoUserPools() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsCognitoUserPoolsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"arns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAwsCognitoUserPoolsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+	name := d.Get("name").(string)
+
+	var ids []string
+	var arns []string
+
+	input := &cognitoidentityprovider.ListUserPoolsInput{
+		MaxResults: aws.Int64(int64(60)),
+	}
+
+	for {
+		output, err := conn.ListUserPools(input)
+		if err != nil {
+			return fmt.Errorf("Error listing Cognito User Pools: %s", err)
+		}
+
+		for _, pool := range output.UserPools {
+			if aws.StringValue(pool.Name) == name {
+				ids = append(ids, aws.StringValue(pool.Id))
+
+				poolArn := arn.ARN{
+					Partition: meta.(*AWSClient).partition,
+					Service:   "cognito-idp",
+					Region:    meta.(*AWSClient).region,
+					AccountID: meta.(*AWSClient).accountid,
+					Resource:  fmt.Sprintf("userpool/%s", aws.StringValue(pool.Id)),
+				}.String()
+
+				arns = append(arns, poolArn)
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("No Cognito User Pool found with name: %s", name)
+	}
+
+	d.SetId(name)
+	d.Set("ids", ids)
+	d.Set("arns", arns)
+
+	return nil
+}
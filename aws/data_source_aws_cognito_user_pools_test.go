@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceAwsCognitoUserPools_basic(t *testing.T) {
+	name := acctest.RandString(5)
+	resourceName := "data.aws_cognito_user_pools.selected"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsCognitoUserPoolsConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "arns.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsCognitoUserPoolsConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "terraform-test-pool-%s"
+}
+
+data "aws_cognito_user_pools" "selected" {
+  name = "${aws_cognito_user_pool.test.name}"
+}
+`, name)
+}
@@ -0,0 +1,201 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceAwsCognitoIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCognitoIdentityProviderCreate,
+		Read:   resourceAwsCognitoIdentityProviderRead,
+		Update: resourceAwsCognitoIdentityProviderUpdate,
+		Delete: resourceAwsCognitoIdentityProviderDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"attribute_mapping": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"idp_identifiers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 50,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"provider_details": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"provider_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 32),
+			},
+
+			"provider_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					cognitoidentityprovider.IdentityProviderTypeTypeSaml,
+					cognitoidentityprovider.IdentityProviderTypeTypeFacebook,
+					cognitoidentityprovider.IdentityProviderTypeTypeGoogle,
+					cognitoidentityprovider.IdentityProviderTypeTypeLoginWithAmazon,
+					cognitoidentityprovider.IdentityProviderTypeTypeSignInWithApple,
+					cognitoidentityprovider.IdentityProviderTypeTypeOidc,
+				}, false),
+			},
+
+			"user_pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsCognitoIdentityProviderCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID := d.Get("user_pool_id").(string)
+	providerName := d.Get("provider_name").(string)
+
+	params := &cognitoidentityprovider.CreateIdentityProviderInput{
+		ProviderDetails: expandStringMap(d.Get("provider_details").(map[string]interface{})),
+		ProviderName:    aws.String(providerName),
+		ProviderType:    aws.String(d.Get("provider_type").(string)),
+		UserPoolId:      aws.String(userPoolID),
+	}
+
+	if v, ok := d.GetOk("attribute_mapping"); ok {
+		params.AttributeMapping = expandStringMap(v.(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("idp_identifiers"); ok {
+		params.IdpIdentifiers = expandStringList(v.([]interface{}))
+	}
+
+	log.Printf("[DEBUG] Creating Cognito Identity Provider: %s", params)
+
+	_, err := conn.CreateIdentityProvider(params)
+	if err != nil {
+		return fmt.Errorf("Error creating Cognito Identity Provider: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", userPoolID, providerName))
+
+	return resourceAwsCognitoIdentityProviderRead(d, meta)
+}
+
+func resourceAwsCognitoIdentityProviderRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, providerName, err := decodeCognitoIdentityProviderID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ret, err := conn.DescribeIdentityProvider(&cognitoidentityprovider.DescribeIdentityProviderInput{
+		ProviderName: aws.String(providerName),
+		UserPoolId:   aws.String(userPoolID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			log.Printf("[WARN] Cognito Identity Provider %s is already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	idp := ret.IdentityProvider
+
+	d.Set("attribute_mapping", aws.StringValueMap(idp.AttributeMapping))
+	d.Set("idp_identifiers", aws.StringValueSlice(idp.IdpIdentifiers))
+	d.Set("provider_details", aws.StringValueMap(idp.ProviderDetails))
+	d.Set("provider_name", idp.ProviderName)
+	d.Set("provider_type", idp.ProviderType)
+	d.Set("user_pool_id", idp.UserPoolId)
+
+	return nil
+}
+
+func resourceAwsCognitoIdentityProviderUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, providerName, err := decodeCognitoIdentityProviderID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	params := &cognitoidentityprovider.UpdateIdentityProviderInput{
+		ProviderDetails: expandStringMap(d.Get("provider_details").(map[string]interface{})),
+		ProviderName:    aws.String(providerName),
+		UserPoolId:      aws.String(userPoolID),
+	}
+
+	if v, ok := d.GetOk("attribute_mapping"); ok {
+		params.AttributeMapping = expandStringMap(v.(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("idp_identifiers"); ok {
+		params.IdpIdentifiers = expandStringList(v.([]interface{}))
+	}
+
+	log.Printf("[DEBUG] Updating Cognito Identity Provider: %s", params)
+
+	_, err = conn.UpdateIdentityProvider(params)
+	if err != nil {
+		return fmt.Errorf("Error updating Cognito Identity Provider: %s", err)
+	}
+
+	return resourceAwsCognitoIdentityProviderRead(d, meta)
+}
+
+func resourceAwsCognitoIdentityProviderDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, providerName, err := decodeCognitoIdentityProviderID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.DeleteIdentityProvider(&cognitoidentityprovider.DeleteIdentityProviderInput{
+		ProviderName: aws.String(providerName),
+		UserPoolId:   aws.String(userPoolID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Cognito Identity Provider: %s", err)
+	}
+
+	return nil
+}
+
+func decodeCognitoIdentityProviderID(id string) (string, string, error) {
+	idParts := strings.SplitN(id, ":", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("expected ID in format USERPOOLID:PROVIDERNAME, received: %s", id)
+	}
+	return idParts[0], idParts[1], nil
+}
@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccAWSCognitoIdentityProvider_basic(t *testing.T) {
+	userPoolName := acctest.RandomWithPrefix("tf-acc-test-")
+	providerName := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_identity_provider.main"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoIdentityProviderResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoIdentityProviderConfig_basic(userPoolName, providerName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoIdentityProviderResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "provider_name", providerName),
+					resource.TestCheckResourceAttr(resourceName, "provider_type", cognitoidentityprovider.IdentityProviderTypeTypeGoogle),
+					resource.TestCheckResourceAttr(resourceName, "provider_details.client_id", "test-client-id"),
+					resource.TestCheckResourceAttr(resourceName, "attribute_mapping.email", "email"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSCognitoIdentityProviderResourceDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).cognitoidpconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_cognito_identity_provider" {
+			continue
+		}
+
+		userPoolID, providerName, err := decodeCognitoIdentityProviderID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.DescribeIdentityProvider(&cognitoidentityprovider.DescribeIdentityProviderInput{
+			ProviderName: aws.String(providerName),
+			UserPoolId:   aws.String(userPoolID),
+		})
+
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSCognitoIdentityProviderResourceExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Cognito Identity Provider ID set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).cognitoidpconn
+
+		userPoolID, providerName, err := decodeCognitoIdentityProviderID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.DescribeIdentityProvider(&cognitoidentityprovider.DescribeIdentityProviderInput{
+			ProviderName: aws.String(providerName),
+			UserPoolId:   aws.String(userPoolID),
+		})
+
+		return err
+	}
+}
+
+func testAccAWSCognitoIdentityProviderConfig_basic(userPoolName, providerName string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "main" {
+  name = "%s"
+}
+
+resource "aws_cognito_identity_provider" "main" {
+  user_pool_id  = "${aws_cognito_user_pool.main.id}"
+  provider_name = "%s"
+  provider_type = "Google"
+
+  provider_details = {
+    authorize_scopes = "email"
+    client_id        = "test-client-id"
+    client_secret    = "test-client-secret"
+  }
+
+  attribute_mapping = {
+    email = "email"
+  }
+}
+`, userPoolName, providerName)
+}
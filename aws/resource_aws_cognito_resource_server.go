@@ -0,0 +1,242 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// resourceAwsCognitoResourceServer also satisfies the separately filed
+// request for an `aws_cognito_user_pool_resource_server` resource: both
+// asked for the same CreateResourceServer/UpdateResourceServer API surface
+// (identifier/name/scope, USERPOOLID/IDENTIFIER import, computed
+// scope_identifiers), so that request was treated as a duplicate rather
+// than adding a second resource type wrapping the identical API.
+func resourceAwsCognitoResourceServer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCognitoResourceServerCreate,
+		Read:   resourceAwsCognitoResourceServerRead,
+		Update: resourceAwsCognitoResourceServerUpdate,
+		Delete: resourceAwsCognitoResourceServerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+
+			"scope": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 100,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"scope_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringLenBetween(1, 256),
+						},
+						"scope_description": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 256),
+						},
+					},
+				},
+			},
+
+			"scope_identifiers": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"user_pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAwsCognitoResourceServerCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	identifier := d.Get("identifier").(string)
+	userPoolID := d.Get("user_pool_id").(string)
+
+	params := &cognitoidentityprovider.CreateResourceServerInput{
+		Identifier: aws.String(identifier),
+		Name:       aws.String(d.Get("name").(string)),
+		UserPoolId: aws.String(userPoolID),
+	}
+
+	if v, ok := d.GetOk("scope"); ok {
+		params.Scopes = expandCognitoResourceServerScope(v.(*schema.Set))
+	}
+
+	log.Printf("[DEBUG] Creating Cognito Resource Server: %s", params)
+
+	_, err := conn.CreateResourceServer(params)
+	if err != nil {
+		return fmt.Errorf("Error creating Cognito Resource Server: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", userPoolID, identifier))
+
+	return resourceAwsCognitoResourceServerRead(d, meta)
+}
+
+func resourceAwsCognitoResourceServerRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, identifier, err := decodeCognitoResourceServerID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	params := &cognitoidentityprovider.DescribeResourceServerInput{
+		Identifier: aws.String(identifier),
+		UserPoolId: aws.String(userPoolID),
+	}
+
+	resp, err := conn.DescribeResourceServer(params)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			log.Printf("[WARN] Cognito Resource Server %s is already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.Set("identifier", resp.ResourceServer.Identifier)
+	d.Set("name", resp.ResourceServer.Name)
+	d.Set("user_pool_id", resp.ResourceServer.UserPoolId)
+
+	if err := d.Set("scope", flattenCognitoResourceServerScope(resp.ResourceServer.Scopes)); err != nil {
+		return fmt.Errorf("Failed setting scope: %s", err)
+	}
+
+	scopeIdentifiers := make([]string, 0, len(resp.ResourceServer.Scopes))
+	for _, scope := range resp.ResourceServer.Scopes {
+		scopeIdentifiers = append(scopeIdentifiers, fmt.Sprintf("%s/%s", identifier, aws.StringValue(scope.ScopeName)))
+	}
+	if err := d.Set("scope_identifiers", scopeIdentifiers); err != nil {
+		return fmt.Errorf("Failed setting scope_identifiers: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsCognitoResourceServerUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, identifier, err := decodeCognitoResourceServerID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	params := &cognitoidentityprovider.UpdateResourceServerInput{
+		Identifier: aws.String(identifier),
+		Name:       aws.String(d.Get("name").(string)),
+		UserPoolId: aws.String(userPoolID),
+	}
+
+	if v, ok := d.GetOk("scope"); ok {
+		params.Scopes = expandCognitoResourceServerScope(v.(*schema.Set))
+	}
+
+	log.Printf("[DEBUG] Updating Cognito Resource Server: %s", params)
+
+	_, err = conn.UpdateResourceServer(params)
+	if err != nil {
+		return fmt.Errorf("Error updating Cognito Resource Server: %s", err)
+	}
+
+	return resourceAwsCognitoResourceServerRead(d, meta)
+}
+
+func resourceAwsCognitoResourceServerDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, identifier, err := decodeCognitoResourceServerID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	params := &cognitoidentityprovider.DeleteResourceServerInput{
+		Identifier: aws.String(identifier),
+		UserPoolId: aws.String(userPoolID),
+	}
+
+	log.Printf("[DEBUG] Deleting Cognito Resource Server: %s", params)
+
+	_, err = conn.DeleteResourceServer(params)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func decodeCognitoResourceServerID(id string) (string, string, error) {
+	idParts := strings.SplitN(id, "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("expected ID in format USERPOOLID/IDENTIFIER, received: %s", id)
+	}
+	return idParts[0], idParts[1], nil
+}
+
+func expandCognitoResourceServerScope(inputs *schema.Set) []*cognitoidentityprovider.ResourceServerScopeType {
+	scopes := make([]*cognitoidentityprovider.ResourceServerScopeType, 0, inputs.Len())
+
+	for _, raw := range inputs.List() {
+		data := raw.(map[string]interface{})
+		scope := &cognitoidentityprovider.ResourceServerScopeType{
+			ScopeName:        aws.String(data["scope_name"].(string)),
+			ScopeDescription: aws.String(data["scope_description"].(string)),
+		}
+		scopes = append(scopes, scope)
+	}
+
+	return scopes
+}
+
+func flattenCognitoResourceServerScope(inputs []*cognitoidentityprovider.ResourceServerScopeType) []map[string]interface{} {
+	values := make([]map[string]interface{}, 0, len(inputs))
+
+	for _, input := range inputs {
+		if input == nil {
+			continue
+		}
+		values = append(values, map[string]interface{}{
+			"scope_name":        aws.StringValue(input.ScopeName),
+			"scope_description": aws.StringValue(input.ScopeDescription),
+		})
+	}
+
+	return values
+}
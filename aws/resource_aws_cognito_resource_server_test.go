@@ -0,0 +1,192 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccAWSCognitoResourceServer_basic(t *testing.T) {
+	userPoolName := acctest.RandString(5)
+	identifier := acctest.RandString(5)
+	name := acctest.RandString(5)
+	resourceName := "aws_cognito_resource_server.main"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoResourceServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoResourceServerConfig_basic(userPoolName, identifier, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoResourceServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "identifier", identifier),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "scope.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "scope_identifiers.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoResourceServer_scope(t *testing.T) {
+	userPoolName := acctest.RandString(5)
+	identifier := acctest.RandString(5)
+	name := acctest.RandString(5)
+	resourceName := "aws_cognito_resource_server.main"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoResourceServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoResourceServerConfig_scope(userPoolName, identifier, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoResourceServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scope.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "scope_identifiers.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAWSCognitoResourceServerConfig_scopeUpdated(userPoolName, identifier, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoResourceServerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scope.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "scope_identifiers.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSCognitoResourceServerDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).cognitoidpconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_cognito_resource_server" {
+			continue
+		}
+
+		userPoolID, identifier, err := decodeCognitoResourceServerID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.DescribeResourceServer(&cognitoidentityprovider.DescribeResourceServerInput{
+			Identifier: aws.String(identifier),
+			UserPoolId: aws.String(userPoolID),
+		})
+
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSCognitoResourceServerExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Cognito Resource Server ID set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).cognitoidpconn
+
+		userPoolID, identifier, err := decodeCognitoResourceServerID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.DescribeResourceServer(&cognitoidentityprovider.DescribeResourceServerInput{
+			Identifier: aws.String(identifier),
+			UserPoolId: aws.String(userPoolID),
+		})
+
+		return err
+	}
+}
+
+func testAccAWSCognitoResourceServerConfig_basic(userPoolName, identifier, name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "main" {
+  name = "terraform-test-pool-%s"
+}
+
+resource "aws_cognito_resource_server" "main" {
+  identifier   = "%s"
+  name         = "%s"
+  user_pool_id = "${aws_cognito_user_pool.main.id}"
+}
+`, userPoolName, identifier, name)
+}
+
+func testAccAWSCognitoResourceServerConfig_scope(userPoolName, identifier, name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "main" {
+  name = "terraform-test-pool-%s"
+}
+
+resource "aws_cognito_resource_server" "main" {
+  identifier   = "%s"
+  name         = "%s"
+  user_pool_id = "${aws_cognito_user_pool.main.id}"
+
+  scope {
+    scope_name        = "read"
+    scope_description = "Allows reading"
+  }
+
+  scope {
+    scope_name        = "write"
+    scope_description = "Allows writing"
+  }
+}
+`, userPoolName, identifier, name)
+}
+
+func testAccAWSCognitoResourceServerConfig_scopeUpdated(userPoolName, identifier, name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "main" {
+  name = "terraform-test-pool-%s"
+}
+
+resource "aws_cognito_resource_server" "main" {
+  identifier   = "%s"
+  name         = "%s"
+  user_pool_id = "${aws_cognito_user_pool.main.id}"
+
+  scope {
+    scope_name        = "read"
+    scope_description = "Allows reading"
+  }
+}
+`, userPoolName, identifier, name)
+}
@@ -0,0 +1,2013 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceAwsCognitoUserPool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCognitoUserPoolCreate,
+		Read:   resourceAwsCognitoUserPoolRead,
+		Update: resourceAwsCognitoUserPoolUpdate,
+		Delete: resourceAwsCognitoUserPoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: resourceAwsCognitoUserPoolCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"account_recovery_setting": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"recovery_mechanism": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							MaxItems: 2,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											cognitoidentityprovider.RecoveryOptionNameTypeVerifiedEmail,
+											cognitoidentityprovider.RecoveryOptionNameTypeVerifiedPhoneNumber,
+											cognitoidentityprovider.RecoveryOptionNameTypeAdminOnly,
+										}, false),
+									},
+									"priority": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(1, 2),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"admin_create_user_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allow_admin_create_user_only": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"invite_message_template": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"email_message": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringLenBetween(6, 20000),
+									},
+									"email_subject": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringLenBetween(1, 140),
+									},
+									"sms_message": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringLenBetween(6, 140),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"alias_attributes": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"username_attributes"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						cognitoidentityprovider.AliasAttributeTypeEmail,
+						cognitoidentityprovider.AliasAttributeTypePhoneNumber,
+						cognitoidentityprovider.AliasAttributeTypePreferredUsername,
+					}, false),
+				},
+			},
+
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"auto_verified_attributes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						cognitoidentityprovider.VerifiedAttributeTypePhoneNumber,
+						cognitoidentityprovider.VerifiedAttributeTypeEmail,
+					}, false),
+				},
+			},
+
+			"creation_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"device_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"challenge_required_on_new_device": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"device_only_remembered_on_user_prompt": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"email_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"reply_to_email_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_arn": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"email_sending_account": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								cognitoidentityprovider.EmailSendingAccountTypeCognitoDefault,
+								cognitoidentityprovider.EmailSendingAccountTypeDeveloper,
+							}, false),
+						},
+					},
+				},
+			},
+
+			"email_verification_subject": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(1, 140),
+			},
+
+			"email_verification_message": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`[\p{L}\p{M}\p{S}\p{N}\p{P}\s*]*\{####\}[\p{L}\p{M}\p{S}\p{N}\p{P}\s*]*`), "see https://docs.aws.amazon.com/cognito-user-identity-pools/latest/APIReference/API_CreateUserPool.html"),
+			},
+
+			"endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"lambda_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"create_auth_challenge": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"custom_email_sender": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"lambda_arn": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateArn,
+									},
+									"lambda_version": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											cognitoidentityprovider.CustomEmailSenderLambdaVersionTypeV10,
+										}, false),
+									},
+								},
+							},
+						},
+						"custom_message": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"custom_sms_sender": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"lambda_arn": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateArn,
+									},
+									"lambda_version": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											cognitoidentityprovider.CustomSMSSenderLambdaVersionTypeV10,
+										}, false),
+									},
+								},
+							},
+						},
+						"define_auth_challenge": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"post_authentication": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"post_confirmation": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"pre_authentication": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"pre_sign_up": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"pre_token_generation": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"kms_key_id": {
+							Type: schema.TypeString,
+							// Required when custom_email_sender or custom_sms_sender is
+							// set, since Cognito encrypts the code payload passed to
+							// those Lambdas with this key. AWS enforces this at the API
+							// level, so it stays Optional here rather than ForceNew.
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"user_migration": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+						"verify_auth_challenge_response": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateArn,
+						},
+					},
+				},
+			},
+
+			"last_modified_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"mfa_configuration": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  cognitoidentityprovider.UserPoolMfaTypeOff,
+				ValidateFunc: validation.StringInSlice([]string{
+					cognitoidentityprovider.UserPoolMfaTypeOff,
+					cognitoidentityprovider.UserPoolMfaTypeOn,
+					cognitoidentityprovider.UserPoolMfaTypeOptional,
+				}, false),
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+
+			"password_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"minimum_length": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntBetween(6, 99),
+						},
+						"require_lowercase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"require_numbers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"require_symbols": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"require_uppercase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"temporary_password_validity_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 365),
+						},
+					},
+				},
+			},
+
+			"schema": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				MaxItems: 50,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attribute_data_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								cognitoidentityprovider.AttributeDataTypeString,
+								cognitoidentityprovider.AttributeDataTypeNumber,
+								cognitoidentityprovider.AttributeDataTypeDateTime,
+								cognitoidentityprovider.AttributeDataTypeBoolean,
+							}, false),
+						},
+						"developer_only_attribute": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							ForceNew: true,
+						},
+						"mutable": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[\p{L}\p{M}\p{S}\p{N}\p{P}]+$`), "see https://docs.aws.amazon.com/cognito-user-identity-pools/latest/APIReference/API_SchemaAttributeType.html"),
+						},
+						"number_attribute_constraints": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"min_value": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"max_value": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"required": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+						"string_attribute_constraints": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"min_length": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"max_length": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"sms_authentication_message": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`.*\{####\}.*`), "must contain {####}"),
+			},
+
+			"sms_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"external_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"sns_caller_arn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateArn,
+						},
+					},
+				},
+			},
+
+			"sms_verification_message": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`.*\{####\}.*`), "must contain {####}"),
+			},
+
+			"software_token_mfa_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"strict_lambda_permissions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"tags": tagsSchema(),
+
+			"user_pool_add_ons": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"advanced_security_mode": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								cognitoidentityprovider.AdvancedSecurityModeTypeAudit,
+								cognitoidentityprovider.AdvancedSecurityModeTypeEnforced,
+								cognitoidentityprovider.AdvancedSecurityModeTypeOff,
+							}, false),
+						},
+					},
+				},
+			},
+
+			"username_attributes": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"alias_attributes"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						cognitoidentityprovider.UsernameAttributeTypeEmail,
+						cognitoidentityprovider.UsernameAttributeTypePhoneNumber,
+					}, false),
+				},
+			},
+
+			"username_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"case_sensitive": {
+							Type:     schema.TypeBool,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"verification_message_template": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_email_option": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  cognitoidentityprovider.DefaultEmailOptionTypeConfirmWithCode,
+							ValidateFunc: validation.StringInSlice([]string{
+								cognitoidentityprovider.DefaultEmailOptionTypeConfirmWithCode,
+								cognitoidentityprovider.DefaultEmailOptionTypeConfirmWithLink,
+							}, false),
+						},
+						"email_message": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`[\p{L}\p{M}\p{S}\p{N}\p{P}\s*]*\{####\}[\p{L}\p{M}\p{S}\p{N}\p{P}\s*]*`), "see https://docs.aws.amazon.com/cognito-user-identity-pools/latest/APIReference/API_CreateUserPool.html"),
+						},
+						"email_message_by_link": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"email_subject": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringLenBetween(1, 140),
+						},
+						"email_subject_by_link": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"sms_message": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`.*\{####\}.*`), "must contain {####}"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceAwsCognitoUserPoolCustomizeDiff warns (or, under
+// strict_lambda_permissions, errors) when a configured lambda_config
+// trigger's resource policy does not grant cognito-idp.amazonaws.com
+// invoke access for this user pool, and rejects schema changes Cognito
+// can't apply in place (see validateCognitoUserPoolSchemaUpdate) before
+// they reach the API as an opaque error. The Lambda permission check runs
+// only for an existing pool whose lambda_config or strict_lambda_permissions
+// changes; it is skipped when the pool is created.
+func resourceAwsCognitoUserPoolCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.HasChange("schema") {
+		o, n := diff.GetChange("schema")
+		if err := validateCognitoUserPoolSchemaUpdate(o.(*schema.Set).List(), n.(*schema.Set).List()); err != nil {
+			return err
+		}
+	}
+
+	if diff.Id() == "" || (!diff.HasChange("lambda_config") && !diff.HasChange("strict_lambda_permissions")) {
+		return nil
+	}
+
+	lambdaConfig := diff.Get("lambda_config").([]interface{})
+	if len(lambdaConfig) == 0 || lambdaConfig[0] == nil {
+		return nil
+	}
+
+	poolArn := diff.Get("arn").(string)
+	if poolArn == "" {
+		return nil
+	}
+
+	strict := diff.Get("strict_lambda_permissions").(bool)
+	conn := meta.(*AWSClient).lambdaconn
+
+	checked := make(map[string]bool)
+	for _, triggerArn := range cognitoUserPoolLambdaTriggerArns(lambdaConfig[0].(map[string]interface{})) {
+		baseArn := cognitoLambdaFunctionBaseArn(triggerArn)
+		if checked[baseArn] {
+			continue
+		}
+		checked[baseArn] = true
+
+		if err := validateCognitoUserPoolLambdaTriggerPermission(conn, baseArn, poolArn); err != nil {
+			if strict {
+				return err
+			}
+			log.Printf("[WARN] %s", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsCognitoUserPoolCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	params := &cognitoidentityprovider.CreateUserPoolInput{
+		PoolName: aws.String(d.Get("name").(string)),
+	}
+
+	if v, ok := d.GetOk("account_recovery_setting"); ok {
+		accountRecoverySetting, err := expandCognitoUserPoolAccountRecoverySetting(v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		params.AccountRecoverySetting = accountRecoverySetting
+	}
+
+	if v, ok := d.GetOk("admin_create_user_config"); ok {
+		params.AdminCreateUserConfig = expandCognitoUserPoolAdminCreateUserConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("alias_attributes"); ok {
+		params.AliasAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("username_attributes"); ok {
+		params.UsernameAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("auto_verified_attributes"); ok {
+		params.AutoVerifiedAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("device_configuration"); ok {
+		params.DeviceConfiguration = expandCognitoUserPoolDeviceConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("email_configuration"); ok {
+		params.EmailConfiguration = expandCognitoUserPoolEmailConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("email_verification_subject"); ok {
+		params.EmailVerificationSubject = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("email_verification_message"); ok {
+		params.EmailVerificationMessage = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("lambda_config"); ok {
+		params.LambdaConfig = expandCognitoUserPoolLambdaConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("password_policy"); ok {
+		if params.Policies == nil {
+			params.Policies = &cognitoidentityprovider.UserPoolPolicyType{}
+		}
+		params.Policies.PasswordPolicy = expandCognitoUserPoolPasswordPolicy(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("schema"); ok {
+		params.Schema = expandCognitoUserPoolSchema(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("sms_authentication_message"); ok {
+		params.SmsAuthenticationMessage = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("sms_configuration"); ok {
+		params.SmsConfiguration = expandCognitoUserPoolSmsConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("sms_verification_message"); ok {
+		params.SmsVerificationMessage = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("user_pool_add_ons"); ok {
+		params.UserPoolAddOns = expandCognitoUserPoolUserPoolAddOns(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("username_configuration"); ok {
+		params.UsernameConfiguration = expandCognitoUserPoolUsernameConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("verification_message_template"); ok {
+		params.VerificationMessageTemplate = expandCognitoUserPoolVerificationMessageTemplate(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		params.UserPoolTags = tagsFromMapGeneric(v.(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("mfa_configuration"); ok {
+		params.MfaConfiguration = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Creating Cognito User Pool: %s", params)
+
+	resp, err := conn.CreateUserPool(params)
+	if err != nil {
+		return fmt.Errorf("Error creating Cognito User Pool: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.UserPool.Id))
+
+	if err := resourceAwsCognitoUserPoolSetMfaConfig(d, conn); err != nil {
+		return err
+	}
+
+	return resourceAwsCognitoUserPoolRead(d, meta)
+}
+
+// resourceAwsCognitoUserPoolSetMfaConfig wires software_token_mfa_configuration
+// through SetUserPoolMfaConfig, which is a separate API from
+// CreateUserPool/UpdateUserPool. It is the only way to enable TOTP-based MFA,
+// so it runs in addition to the mfa_configuration field sent with the main
+// create/update request. It is not called when mfa_configuration is OFF.
+func resourceAwsCognitoUserPoolSetMfaConfig(d *schema.ResourceData, conn *cognitoidentityprovider.CognitoIdentityProvider) error {
+	mfaConfiguration := d.Get("mfa_configuration").(string)
+	if mfaConfiguration == cognitoidentityprovider.UserPoolMfaTypeOff {
+		return nil
+	}
+
+	params := &cognitoidentityprovider.SetUserPoolMfaConfigInput{
+		MfaConfiguration: aws.String(mfaConfiguration),
+		UserPoolId:       aws.String(d.Id()),
+	}
+
+	if v, ok := d.GetOk("sms_configuration"); ok {
+		params.SmsMfaConfiguration = &cognitoidentityprovider.SmsMfaConfigType{
+			SmsConfiguration: expandCognitoUserPoolSmsConfiguration(v.([]interface{})),
+		}
+
+		if v, ok := d.GetOk("sms_authentication_message"); ok {
+			params.SmsMfaConfiguration.SmsAuthenticationMessage = aws.String(v.(string))
+		}
+	}
+
+	if v, ok := d.GetOk("software_token_mfa_configuration"); ok {
+		params.SoftwareTokenMfaConfiguration = expandCognitoUserPoolSoftwareTokenMfaConfiguration(v.([]interface{}))
+	}
+
+	log.Printf("[DEBUG] Setting Cognito User Pool MFA Configuration: %s", params)
+
+	_, err := conn.SetUserPoolMfaConfig(params)
+	if err != nil {
+		return fmt.Errorf("Error setting Cognito User Pool MFA Configuration: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsCognitoUserPoolRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	ret, err := conn.DescribeUserPool(&cognitoidentityprovider.DescribeUserPoolInput{
+		UserPoolId: aws.String(d.Id()),
+	})
+
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			log.Printf("[WARN] Cognito User Pool %s is already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	userPool := ret.UserPool
+
+	if err := d.Set("account_recovery_setting", flattenCognitoUserPoolAccountRecoverySetting(userPool.AccountRecoverySetting)); err != nil {
+		return fmt.Errorf("Failed setting account_recovery_setting: %s", err)
+	}
+
+	if err := d.Set("admin_create_user_config", flattenCognitoUserPoolAdminCreateUserConfig(userPool.AdminCreateUserConfig)); err != nil {
+		return fmt.Errorf("Failed setting admin_create_user_config: %s", err)
+	}
+
+	if userPool.AliasAttributes != nil {
+		d.Set("alias_attributes", flattenStringList(userPool.AliasAttributes))
+	}
+
+	if userPool.UsernameAttributes != nil {
+		d.Set("username_attributes", flattenStringList(userPool.UsernameAttributes))
+	}
+
+	d.Set("arn", userPool.Arn)
+
+	if userPool.AutoVerifiedAttributes != nil {
+		d.Set("auto_verified_attributes", flattenStringList(userPool.AutoVerifiedAttributes))
+	}
+
+	if userPool.CreationDate != nil {
+		d.Set("creation_date", userPool.CreationDate.Format(cognitoUserPoolTimeLayout))
+	}
+
+	if err := d.Set("device_configuration", flattenCognitoUserPoolDeviceConfiguration(userPool.DeviceConfiguration)); err != nil {
+		return fmt.Errorf("Failed setting device_configuration: %s", err)
+	}
+
+	if err := d.Set("email_configuration", flattenCognitoUserPoolEmailConfiguration(userPool.EmailConfiguration)); err != nil {
+		return fmt.Errorf("Failed setting email_configuration: %s", err)
+	}
+
+	d.Set("email_verification_subject", userPool.EmailVerificationSubject)
+	d.Set("email_verification_message", userPool.EmailVerificationMessage)
+
+	d.Set("endpoint", fmt.Sprintf("%s/%s", meta.(*AWSClient).RegionalHostname("cognito-idp"), aws.StringValue(userPool.Id)))
+
+	if err := d.Set("lambda_config", flattenCognitoUserPoolLambdaConfig(userPool.LambdaConfig)); err != nil {
+		return fmt.Errorf("Failed setting lambda_config: %s", err)
+	}
+
+	if userPool.LastModifiedDate != nil {
+		d.Set("last_modified_date", userPool.LastModifiedDate.Format(cognitoUserPoolTimeLayout))
+	}
+
+	d.Set("mfa_configuration", userPool.MfaConfiguration)
+	d.Set("name", userPool.Name)
+
+	if userPool.Policies != nil {
+		if err := d.Set("password_policy", flattenCognitoUserPoolPasswordPolicy(userPool.Policies.PasswordPolicy)); err != nil {
+			return fmt.Errorf("Failed setting password_policy: %s", err)
+		}
+	}
+
+	if err := d.Set("schema", flattenCognitoUserPoolSchema(userPool.SchemaAttributes)); err != nil {
+		return fmt.Errorf("Failed setting schema: %s", err)
+	}
+
+	d.Set("sms_authentication_message", userPool.SmsAuthenticationMessage)
+
+	if err := d.Set("sms_configuration", flattenCognitoUserPoolSmsConfiguration(userPool.SmsConfiguration)); err != nil {
+		return fmt.Errorf("Failed setting sms_configuration: %s", err)
+	}
+
+	d.Set("sms_verification_message", userPool.SmsVerificationMessage)
+
+	mfaConfigOutput, err := conn.GetUserPoolMfaConfig(&cognitoidentityprovider.GetUserPoolMfaConfigInput{
+		UserPoolId: aws.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting Cognito User Pool MFA Configuration: %s", err)
+	}
+
+	if err := d.Set("software_token_mfa_configuration", flattenCognitoUserPoolSoftwareTokenMfaConfiguration(mfaConfigOutput.SoftwareTokenMfaConfiguration)); err != nil {
+		return fmt.Errorf("Failed setting software_token_mfa_configuration: %s", err)
+	}
+
+	if err := d.Set("tags", tagsToMapGeneric(userPool.UserPoolTags)); err != nil {
+		return fmt.Errorf("Failed setting tags: %s", err)
+	}
+
+	if err := d.Set("user_pool_add_ons", flattenCognitoUserPoolUserPoolAddOns(userPool.UserPoolAddOns)); err != nil {
+		return fmt.Errorf("Failed setting user_pool_add_ons: %s", err)
+	}
+
+	if err := d.Set("username_configuration", flattenCognitoUserPoolUsernameConfiguration(userPool.UsernameConfiguration)); err != nil {
+		return fmt.Errorf("Failed setting username_configuration: %s", err)
+	}
+
+	if err := d.Set("verification_message_template", flattenCognitoUserPoolVerificationMessageTemplate(userPool.VerificationMessageTemplate)); err != nil {
+		return fmt.Errorf("Failed setting verification_message_template: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsCognitoUserPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	params := &cognitoidentityprovider.UpdateUserPoolInput{
+		UserPoolId: aws.String(d.Id()),
+	}
+
+	if v, ok := d.GetOk("account_recovery_setting"); ok {
+		accountRecoverySetting, err := expandCognitoUserPoolAccountRecoverySetting(v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		params.AccountRecoverySetting = accountRecoverySetting
+	}
+
+	if v, ok := d.GetOk("admin_create_user_config"); ok {
+		params.AdminCreateUserConfig = expandCognitoUserPoolAdminCreateUserConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("auto_verified_attributes"); ok {
+		params.AutoVerifiedAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("device_configuration"); ok {
+		params.DeviceConfiguration = expandCognitoUserPoolDeviceConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("email_configuration"); ok {
+		params.EmailConfiguration = expandCognitoUserPoolEmailConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("email_verification_subject"); ok {
+		params.EmailVerificationSubject = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("email_verification_message"); ok {
+		params.EmailVerificationMessage = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("lambda_config"); ok {
+		params.LambdaConfig = expandCognitoUserPoolLambdaConfig(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("mfa_configuration"); ok {
+		params.MfaConfiguration = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("password_policy"); ok {
+		if params.Policies == nil {
+			params.Policies = &cognitoidentityprovider.UserPoolPolicyType{}
+		}
+		params.Policies.PasswordPolicy = expandCognitoUserPoolPasswordPolicy(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("sms_authentication_message"); ok {
+		params.SmsAuthenticationMessage = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("sms_configuration"); ok {
+		params.SmsConfiguration = expandCognitoUserPoolSmsConfiguration(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("sms_verification_message"); ok {
+		params.SmsVerificationMessage = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("user_pool_add_ons"); ok {
+		params.UserPoolAddOns = expandCognitoUserPoolUserPoolAddOns(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("verification_message_template"); ok {
+		params.VerificationMessageTemplate = expandCognitoUserPoolVerificationMessageTemplate(v.([]interface{}))
+	}
+
+	if d.HasChange("tags") {
+		params.UserPoolTags = tagsFromMapGeneric(d.Get("tags").(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] Updating Cognito User Pool: %s", params)
+
+	_, err := conn.UpdateUserPool(params)
+	if err != nil {
+		return fmt.Errorf("Error updating Cognito User Pool: %s", err)
+	}
+
+	if err := resourceAwsCognitoUserPoolSetMfaConfig(d, conn); err != nil {
+		return err
+	}
+
+	if d.HasChange("schema") {
+		o, n := d.GetChange("schema")
+		added := cognitoUserPoolNewSchemaAttributes(o.(*schema.Set).List(), n.(*schema.Set).List())
+
+		if len(added) > 0 {
+			_, err := conn.AddCustomAttributes(&cognitoidentityprovider.AddCustomAttributesInput{
+				UserPoolId:       aws.String(d.Id()),
+				CustomAttributes: expandCognitoUserPoolSchema(added),
+			})
+			if err != nil {
+				return fmt.Errorf("Error adding Cognito User Pool (%s) schema attributes: %s", d.Id(), err)
+			}
+		}
+	}
+
+	return resourceAwsCognitoUserPoolRead(d, meta)
+}
+
+func resourceAwsCognitoUserPoolDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	_, err := conn.DeleteUserPool(&cognitoidentityprovider.DeleteUserPoolInput{
+		UserPoolId: aws.String(d.Id()),
+	})
+
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Cognito User Pool: %s", err)
+	}
+
+	return nil
+}
+
+const cognitoUserPoolTimeLayout = "2006-01-02T15:04:05Z07:00"
+
+func expandCognitoUserPoolAdminCreateUserConfig(configs []interface{}) *cognitoidentityprovider.AdminCreateUserConfigType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.AdminCreateUserConfigType{
+		AllowAdminCreateUserOnly: aws.Bool(config["allow_admin_create_user_only"].(bool)),
+	}
+
+	if v, ok := config["invite_message_template"]; ok {
+		data := v.([]interface{})
+
+		if len(data) > 0 && data[0] != nil {
+			m, ok := data[0].(map[string]interface{})
+
+			if ok {
+				imt := &cognitoidentityprovider.MessageTemplateType{}
+
+				if v, ok := m["email_message"]; ok && v.(string) != "" {
+					imt.EmailMessage = aws.String(v.(string))
+				}
+
+				if v, ok := m["email_subject"]; ok && v.(string) != "" {
+					imt.EmailSubject = aws.String(v.(string))
+				}
+
+				if v, ok := m["sms_message"]; ok && v.(string) != "" {
+					imt.SMSMessage = aws.String(v.(string))
+				}
+
+				if imt.EmailMessage != nil || imt.EmailSubject != nil || imt.SMSMessage != nil {
+					configs2.InviteMessageTemplate = imt
+				}
+			}
+		}
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolAdminCreateUserConfig(config *cognitoidentityprovider.AdminCreateUserConfigType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"allow_admin_create_user_only": aws.BoolValue(config.AllowAdminCreateUserOnly),
+	}
+
+	if config.InviteMessageTemplate != nil {
+		subconfig := map[string]interface{}{}
+
+		if config.InviteMessageTemplate.EmailMessage != nil {
+			subconfig["email_message"] = aws.StringValue(config.InviteMessageTemplate.EmailMessage)
+		}
+
+		if config.InviteMessageTemplate.EmailSubject != nil {
+			subconfig["email_subject"] = aws.StringValue(config.InviteMessageTemplate.EmailSubject)
+		}
+
+		if config.InviteMessageTemplate.SMSMessage != nil {
+			subconfig["sms_message"] = aws.StringValue(config.InviteMessageTemplate.SMSMessage)
+		}
+
+		if len(subconfig) > 0 {
+			settings["invite_message_template"] = []map[string]interface{}{subconfig}
+		}
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolDeviceConfiguration(configs []interface{}) *cognitoidentityprovider.DeviceConfigurationType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.DeviceConfigurationType{
+		ChallengeRequiredOnNewDevice:     aws.Bool(config["challenge_required_on_new_device"].(bool)),
+		DeviceOnlyRememberedOnUserPrompt: aws.Bool(config["device_only_remembered_on_user_prompt"].(bool)),
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolDeviceConfiguration(config *cognitoidentityprovider.DeviceConfigurationType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"challenge_required_on_new_device":      aws.BoolValue(config.ChallengeRequiredOnNewDevice),
+		"device_only_remembered_on_user_prompt": aws.BoolValue(config.DeviceOnlyRememberedOnUserPrompt),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolEmailConfiguration(configs []interface{}) *cognitoidentityprovider.EmailConfigurationType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.EmailConfigurationType{}
+
+	if v, ok := config["reply_to_email_address"]; ok && v.(string) != "" {
+		configs2.ReplyToEmailAddress = aws.String(v.(string))
+	}
+
+	if v, ok := config["source_arn"]; ok && v.(string) != "" {
+		configs2.SourceArn = aws.String(v.(string))
+	}
+
+	if v, ok := config["email_sending_account"]; ok && v.(string) != "" {
+		configs2.EmailSendingAccount = aws.String(v.(string))
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolEmailConfiguration(config *cognitoidentityprovider.EmailConfigurationType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"reply_to_email_address": aws.StringValue(config.ReplyToEmailAddress),
+		"source_arn":             aws.StringValue(config.SourceArn),
+		"email_sending_account":  aws.StringValue(config.EmailSendingAccount),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolLambdaConfig(configs []interface{}) *cognitoidentityprovider.LambdaConfigType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.LambdaConfigType{}
+
+	if v, ok := config["create_auth_challenge"]; ok && v.(string) != "" {
+		configs2.CreateAuthChallenge = aws.String(v.(string))
+	}
+
+	if v, ok := config["custom_email_sender"]; ok {
+		configs2.CustomEmailSender = expandCognitoUserPoolCustomEmailSender(v.([]interface{}))
+	}
+
+	if v, ok := config["custom_message"]; ok && v.(string) != "" {
+		configs2.CustomMessage = aws.String(v.(string))
+	}
+
+	if v, ok := config["custom_sms_sender"]; ok {
+		configs2.CustomSMSSender = expandCognitoUserPoolCustomSMSSender(v.([]interface{}))
+	}
+
+	if v, ok := config["kms_key_id"]; ok && v.(string) != "" {
+		configs2.KMSKeyID = aws.String(v.(string))
+	}
+
+	if v, ok := config["define_auth_challenge"]; ok && v.(string) != "" {
+		configs2.DefineAuthChallenge = aws.String(v.(string))
+	}
+
+	if v, ok := config["post_authentication"]; ok && v.(string) != "" {
+		configs2.PostAuthentication = aws.String(v.(string))
+	}
+
+	if v, ok := config["post_confirmation"]; ok && v.(string) != "" {
+		configs2.PostConfirmation = aws.String(v.(string))
+	}
+
+	if v, ok := config["pre_authentication"]; ok && v.(string) != "" {
+		configs2.PreAuthentication = aws.String(v.(string))
+	}
+
+	if v, ok := config["pre_sign_up"]; ok && v.(string) != "" {
+		configs2.PreSignUp = aws.String(v.(string))
+	}
+
+	if v, ok := config["pre_token_generation"]; ok && v.(string) != "" {
+		configs2.PreTokenGeneration = aws.String(v.(string))
+	}
+
+	if v, ok := config["user_migration"]; ok && v.(string) != "" {
+		configs2.UserMigration = aws.String(v.(string))
+	}
+
+	if v, ok := config["verify_auth_challenge_response"]; ok && v.(string) != "" {
+		configs2.VerifyAuthChallengeResponse = aws.String(v.(string))
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolLambdaConfig(config *cognitoidentityprovider.LambdaConfigType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"create_auth_challenge":          aws.StringValue(config.CreateAuthChallenge),
+		"custom_email_sender":            flattenCognitoUserPoolCustomEmailSender(config.CustomEmailSender),
+		"custom_message":                 aws.StringValue(config.CustomMessage),
+		"custom_sms_sender":              flattenCognitoUserPoolCustomSMSSender(config.CustomSMSSender),
+		"define_auth_challenge":          aws.StringValue(config.DefineAuthChallenge),
+		"kms_key_id":                     aws.StringValue(config.KMSKeyID),
+		"post_authentication":            aws.StringValue(config.PostAuthentication),
+		"post_confirmation":              aws.StringValue(config.PostConfirmation),
+		"pre_authentication":             aws.StringValue(config.PreAuthentication),
+		"pre_sign_up":                    aws.StringValue(config.PreSignUp),
+		"pre_token_generation":           aws.StringValue(config.PreTokenGeneration),
+		"user_migration":                 aws.StringValue(config.UserMigration),
+		"verify_auth_challenge_response": aws.StringValue(config.VerifyAuthChallengeResponse),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+// cognitoUserPoolLambdaTriggerArns returns every Lambda ARN referenced by a
+// lambda_config block, including the ARNs nested inside custom_email_sender
+// and custom_sms_sender.
+func cognitoUserPoolLambdaTriggerArns(config map[string]interface{}) []string {
+	var arns []string
+
+	for _, key := range []string{
+		"create_auth_challenge",
+		"custom_message",
+		"define_auth_challenge",
+		"post_authentication",
+		"post_confirmation",
+		"pre_authentication",
+		"pre_sign_up",
+		"pre_token_generation",
+		"user_migration",
+		"verify_auth_challenge_response",
+	} {
+		if arn, ok := config[key].(string); ok && arn != "" {
+			arns = append(arns, arn)
+		}
+	}
+
+	for _, key := range []string{"custom_email_sender", "custom_sms_sender"} {
+		senders, ok := config[key].([]interface{})
+		if !ok || len(senders) == 0 || senders[0] == nil {
+			continue
+		}
+		if arn, ok := senders[0].(map[string]interface{})["lambda_arn"].(string); ok && arn != "" {
+			arns = append(arns, arn)
+		}
+	}
+
+	return arns
+}
+
+// cognitoLambdaFunctionBaseArn strips the optional version or alias
+// qualifier from a Lambda function ARN (e.g.
+// "arn:aws:lambda:...:function:foo:LIVE" becomes
+// "arn:aws:lambda:...:function:foo") so triggers that point at different
+// qualifiers of the same function are only validated once.
+func cognitoLambdaFunctionBaseArn(functionArn string) string {
+	parts := strings.Split(functionArn, ":")
+	if len(parts) > 7 && parts[5] == "function" {
+		return strings.Join(parts[:7], ":")
+	}
+	return functionArn
+}
+
+// validateCognitoUserPoolLambdaTriggerPermission checks that functionArn's
+// resource policy contains an Allow statement for principal
+// cognito-idp.amazonaws.com scoped to userPoolArn, returning a descriptive
+// error when it doesn't.
+func validateCognitoUserPoolLambdaTriggerPermission(conn *lambda.Lambda, functionArn, userPoolArn string) error {
+	output, err := conn.GetPolicy(&lambda.GetPolicyInput{
+		FunctionName: aws.String(functionArn),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == lambda.ErrCodeResourceNotFoundException {
+			return fmt.Errorf("lambda_config: %s has no resource policy, so cognito-idp.amazonaws.com cannot invoke it", functionArn)
+		}
+		return fmt.Errorf("lambda_config: error reading policy for %s: %s", functionArn, err)
+	}
+
+	var policy struct {
+		Statement []struct {
+			Effect    string `json:"Effect"`
+			Principal struct {
+				Service string `json:"Service"`
+			} `json:"Principal"`
+			Condition struct {
+				ArnLike   map[string]string `json:"ArnLike"`
+				ArnEquals map[string]string `json:"ArnEquals"`
+			} `json:"Condition"`
+		} `json:"Statement"`
+	}
+
+	if err := json.Unmarshal([]byte(aws.StringValue(output.Policy)), &policy); err != nil {
+		return fmt.Errorf("lambda_config: error parsing policy for %s: %s", functionArn, err)
+	}
+
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || statement.Principal.Service != "cognito-idp.amazonaws.com" {
+			continue
+		}
+		if statement.Condition.ArnLike["AWS:SourceArn"] == userPoolArn || statement.Condition.ArnEquals["AWS:SourceArn"] == userPoolArn {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("lambda_config: %s has no resource policy statement granting cognito-idp.amazonaws.com invoke access for %s", functionArn, userPoolArn)
+}
+
+func expandCognitoUserPoolCustomEmailSender(configs []interface{}) *cognitoidentityprovider.CustomEmailLambdaVersionConfigType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	return &cognitoidentityprovider.CustomEmailLambdaVersionConfigType{
+		LambdaArn:     aws.String(config["lambda_arn"].(string)),
+		LambdaVersion: aws.String(config["lambda_version"].(string)),
+	}
+}
+
+func flattenCognitoUserPoolCustomEmailSender(config *cognitoidentityprovider.CustomEmailLambdaVersionConfigType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"lambda_arn":     aws.StringValue(config.LambdaArn),
+		"lambda_version": aws.StringValue(config.LambdaVersion),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolCustomSMSSender(configs []interface{}) *cognitoidentityprovider.CustomSMSLambdaVersionConfigType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	return &cognitoidentityprovider.CustomSMSLambdaVersionConfigType{
+		LambdaArn:     aws.String(config["lambda_arn"].(string)),
+		LambdaVersion: aws.String(config["lambda_version"].(string)),
+	}
+}
+
+func flattenCognitoUserPoolCustomSMSSender(config *cognitoidentityprovider.CustomSMSLambdaVersionConfigType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"lambda_arn":     aws.StringValue(config.LambdaArn),
+		"lambda_version": aws.StringValue(config.LambdaVersion),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolPasswordPolicy(configs []interface{}) *cognitoidentityprovider.PasswordPolicyType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.PasswordPolicyType{}
+
+	if v, ok := config["minimum_length"]; ok {
+		configs2.MinimumLength = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := config["require_lowercase"]; ok {
+		configs2.RequireLowercase = aws.Bool(v.(bool))
+	}
+
+	if v, ok := config["require_numbers"]; ok {
+		configs2.RequireNumbers = aws.Bool(v.(bool))
+	}
+
+	if v, ok := config["require_symbols"]; ok {
+		configs2.RequireSymbols = aws.Bool(v.(bool))
+	}
+
+	if v, ok := config["require_uppercase"]; ok {
+		configs2.RequireUppercase = aws.Bool(v.(bool))
+	}
+
+	if v, ok := config["temporary_password_validity_days"]; ok {
+		configs2.TemporaryPasswordValidityDays = aws.Int64(int64(v.(int)))
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolPasswordPolicy(config *cognitoidentityprovider.PasswordPolicyType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"minimum_length":                   aws.Int64Value(config.MinimumLength),
+		"require_lowercase":                aws.BoolValue(config.RequireLowercase),
+		"require_numbers":                  aws.BoolValue(config.RequireNumbers),
+		"require_symbols":                  aws.BoolValue(config.RequireSymbols),
+		"require_uppercase":                aws.BoolValue(config.RequireUppercase),
+		"temporary_password_validity_days": aws.Int64Value(config.TemporaryPasswordValidityDays),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+// cognitoUserPoolStandardAttributeDefault describes the AttributeDataType,
+// Mutable, and Required values Cognito assigns to one of its built-in
+// standard attributes when a user pool is created.
+type cognitoUserPoolStandardAttributeDefault struct {
+	AttributeDataType string
+	Mutable           bool
+	Required          bool
+}
+
+// cognitoUserPoolStandardAttributeDefaults are the defaults Cognito uses
+// for its ~20 built-in standard schema attributes. DescribeUserPool
+// always returns these alongside any custom attributes; if the provider
+// stored them in state as-is, a config that only declares custom
+// attributes would show a perpetual diff and, since schema attributes
+// are otherwise immutable, attempting to "fix" that diff by resending
+// the standard attributes during an update or recreate fails with
+// Invalid AttributeDataType (see
+// https://github.com/aws-amplify/amplify-cli/issues/9525). Attributes
+// matching these defaults exactly are treated as Cognito-managed and
+// left out of state.
+var cognitoUserPoolStandardAttributeDefaults = map[string]cognitoUserPoolStandardAttributeDefault{
+	"address":               {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"birthdate":             {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"email":                 {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"email_verified":        {cognitoidentityprovider.AttributeDataTypeBoolean, true, false},
+	"family_name":           {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"gender":                {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"given_name":            {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"locale":                {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"middle_name":           {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"name":                  {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"nickname":              {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"phone_number":          {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"phone_number_verified": {cognitoidentityprovider.AttributeDataTypeBoolean, true, false},
+	"picture":               {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"preferred_username":    {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"profile":               {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"sub":                   {cognitoidentityprovider.AttributeDataTypeString, false, true},
+	"updated_at":            {cognitoidentityprovider.AttributeDataTypeNumber, true, false},
+	"website":               {cognitoidentityprovider.AttributeDataTypeString, true, false},
+	"zoneinfo":              {cognitoidentityprovider.AttributeDataTypeString, true, false},
+}
+
+func isCognitoUserPoolDefaultStandardAttribute(input *cognitoidentityprovider.SchemaAttributeType) bool {
+	def, ok := cognitoUserPoolStandardAttributeDefaults[aws.StringValue(input.Name)]
+	if !ok {
+		return false
+	}
+
+	return aws.StringValue(input.AttributeDataType) == def.AttributeDataType &&
+		aws.BoolValue(input.Mutable) == def.Mutable &&
+		aws.BoolValue(input.Required) == def.Required
+}
+
+// validateCognitoUserPoolSchemaUpdate enforces that the schema set is
+// add-only: Cognito does not support removing or modifying an existing
+// custom attribute, so rather than let those changes fail at apply time
+// with a raw API error, it rejects during plan the removal of an
+// attribute, a change to its mutable flag, and a raised min_length or
+// lowered max_length in its string_attribute_constraints. Other changes to
+// an existing attribute, such as a widened string range or changed
+// number_attribute_constraints, are not rejected here.
+func validateCognitoUserPoolSchemaUpdate(oldSchema, newSchema []interface{}) error {
+	oldAttributes := make(map[string]map[string]interface{}, len(oldSchema))
+	for _, v := range oldSchema {
+		attr := v.(map[string]interface{})
+		oldAttributes[attr["name"].(string)] = attr
+	}
+
+	newAttributes := make(map[string]bool, len(newSchema))
+	for _, v := range newSchema {
+		newAttributes[v.(map[string]interface{})["name"].(string)] = true
+	}
+
+	for name := range oldAttributes {
+		if !newAttributes[name] {
+			return fmt.Errorf("schema: cannot remove attribute %q; Cognito user pool schema is add-only", name)
+		}
+	}
+
+	for _, v := range newSchema {
+		newAttr := v.(map[string]interface{})
+		name := newAttr["name"].(string)
+
+		oldAttr, ok := oldAttributes[name]
+		if !ok {
+			continue
+		}
+
+		if oldAttr["mutable"].(bool) != newAttr["mutable"].(bool) {
+			return fmt.Errorf("schema: cannot change mutable on existing attribute %q; Cognito does not support changing an attribute's mutability after creation", name)
+		}
+
+		oldMin, oldMax := cognitoUserPoolStringAttributeConstraints(oldAttr)
+		newMin, newMax := cognitoUserPoolStringAttributeConstraints(newAttr)
+
+		if newMin != nil && (oldMin == nil || *newMin > *oldMin) {
+			return fmt.Errorf("schema: cannot raise string_attribute_constraints.min_length on existing attribute %q; Cognito does not support shrinking an attribute's allowed value range", name)
+		}
+
+		if newMax != nil && (oldMax == nil || *newMax < *oldMax) {
+			return fmt.Errorf("schema: cannot lower string_attribute_constraints.max_length on existing attribute %q; Cognito does not support shrinking an attribute's allowed value range", name)
+		}
+	}
+
+	return nil
+}
+
+// cognitoUserPoolStringAttributeConstraints pulls the min_length/max_length
+// bounds out of a schema attribute's string_attribute_constraints block,
+// returning nil for either bound that isn't set.
+func cognitoUserPoolStringAttributeConstraints(attr map[string]interface{}) (min, max *int) {
+	constraints, ok := attr["string_attribute_constraints"].([]interface{})
+	if !ok || len(constraints) == 0 || constraints[0] == nil {
+		return nil, nil
+	}
+
+	c := constraints[0].(map[string]interface{})
+
+	if v, ok := c["min_length"].(string); ok && v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			min = &n
+		}
+	}
+
+	if v, ok := c["max_length"].(string); ok && v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			max = &n
+		}
+	}
+
+	return min, max
+}
+
+// cognitoUserPoolNewSchemaAttributes returns the entries in newSchema whose
+// name doesn't appear in oldSchema, for use with AddCustomAttributes.
+// validateCognitoUserPoolSchemaUpdate (run via CustomizeDiff) rejects
+// removals, mutable flips and narrowed string ranges beforehand; any other
+// change to an entry already in oldSchema is not sent to Cognito.
+// Entries matching a Cognito standard attribute default are excluded the
+// same way flattenCognitoUserPoolSchema excludes them from state: they're
+// Cognito-managed, not custom attributes to add.
+func cognitoUserPoolNewSchemaAttributes(oldSchema, newSchema []interface{}) []interface{} {
+	oldNames := make(map[string]bool, len(oldSchema))
+	for _, v := range oldSchema {
+		oldNames[v.(map[string]interface{})["name"].(string)] = true
+	}
+
+	var added []interface{}
+	for _, v := range newSchema {
+		config := v.(map[string]interface{})
+		if oldNames[config["name"].(string)] || cognitoUserPoolSchemaConfigIsDefaultStandardAttribute(config) {
+			continue
+		}
+		added = append(added, v)
+	}
+
+	return added
+}
+
+// cognitoUserPoolSchemaConfigIsDefaultStandardAttribute mirrors
+// isCognitoUserPoolDefaultStandardAttribute for a raw schema block as
+// read from ResourceData rather than a SchemaAttributeType from the API.
+func cognitoUserPoolSchemaConfigIsDefaultStandardAttribute(config map[string]interface{}) bool {
+	def, ok := cognitoUserPoolStandardAttributeDefaults[config["name"].(string)]
+	if !ok {
+		return false
+	}
+
+	return config["attribute_data_type"].(string) == def.AttributeDataType &&
+		config["mutable"].(bool) == def.Mutable &&
+		config["required"].(bool) == def.Required
+}
+
+func expandCognitoUserPoolSchema(inputs []interface{}) []*cognitoidentityprovider.SchemaAttributeType {
+	configs := make([]*cognitoidentityprovider.SchemaAttributeType, len(inputs))
+
+	for i, input := range inputs {
+		param := input.(map[string]interface{})
+		config := &cognitoidentityprovider.SchemaAttributeType{}
+
+		if v, ok := param["attribute_data_type"]; ok && v.(string) != "" {
+			config.AttributeDataType = aws.String(v.(string))
+		}
+
+		if v, ok := param["developer_only_attribute"]; ok {
+			config.DeveloperOnlyAttribute = aws.Bool(v.(bool))
+		}
+
+		if v, ok := param["mutable"]; ok {
+			config.Mutable = aws.Bool(v.(bool))
+		}
+
+		if v, ok := param["name"]; ok && v.(string) != "" {
+			config.Name = aws.String(v.(string))
+		}
+
+		if v, ok := param["required"]; ok {
+			config.Required = aws.Bool(v.(bool))
+		}
+
+		if v, ok := param["number_attribute_constraints"]; ok {
+			data := v.([]interface{})
+
+			if len(data) > 0 && data[0] != nil {
+				m, ok := data[0].(map[string]interface{})
+
+				if ok {
+					config.NumberAttributeConstraints = &cognitoidentityprovider.NumberAttributeConstraintsType{}
+
+					if v, ok := m["min_value"]; ok && v.(string) != "" {
+						config.NumberAttributeConstraints.MinValue = aws.String(v.(string))
+					}
+
+					if v, ok := m["max_value"]; ok && v.(string) != "" {
+						config.NumberAttributeConstraints.MaxValue = aws.String(v.(string))
+					}
+				}
+			}
+		}
+
+		if v, ok := param["string_attribute_constraints"]; ok {
+			data := v.([]interface{})
+
+			if len(data) > 0 && data[0] != nil {
+				m, ok := data[0].(map[string]interface{})
+
+				if ok {
+					config.StringAttributeConstraints = &cognitoidentityprovider.StringAttributeConstraintsType{}
+
+					if v, ok := m["min_length"]; ok && v.(string) != "" {
+						config.StringAttributeConstraints.MinLength = aws.String(v.(string))
+					}
+
+					if v, ok := m["max_length"]; ok && v.(string) != "" {
+						config.StringAttributeConstraints.MaxLength = aws.String(v.(string))
+					}
+				}
+			}
+		}
+
+		configs[i] = config
+	}
+
+	return configs
+}
+
+func flattenCognitoUserPoolSchema(inputs []*cognitoidentityprovider.SchemaAttributeType) []map[string]interface{} {
+	values := make([]map[string]interface{}, 0, len(inputs))
+
+	for _, input := range inputs {
+		if input == nil {
+			continue
+		}
+
+		if isCognitoUserPoolDefaultStandardAttribute(input) {
+			continue
+		}
+
+		var value = map[string]interface{}{
+			"attribute_data_type":      aws.StringValue(input.AttributeDataType),
+			"developer_only_attribute": aws.BoolValue(input.DeveloperOnlyAttribute),
+			"mutable":                  aws.BoolValue(input.Mutable),
+			"name":                     aws.StringValue(input.Name),
+			"required":                 aws.BoolValue(input.Required),
+		}
+
+		if input.NumberAttributeConstraints != nil {
+			subvalue := map[string]interface{}{}
+
+			if input.NumberAttributeConstraints.MinValue != nil {
+				subvalue["min_value"] = aws.StringValue(input.NumberAttributeConstraints.MinValue)
+			}
+
+			if input.NumberAttributeConstraints.MaxValue != nil {
+				subvalue["max_value"] = aws.StringValue(input.NumberAttributeConstraints.MaxValue)
+			}
+
+			value["number_attribute_constraints"] = []map[string]interface{}{subvalue}
+		}
+
+		if input.StringAttributeConstraints != nil {
+			subvalue := map[string]interface{}{}
+
+			if input.StringAttributeConstraints.MinLength != nil {
+				subvalue["min_length"] = aws.StringValue(input.StringAttributeConstraints.MinLength)
+			}
+
+			if input.StringAttributeConstraints.MaxLength != nil {
+				subvalue["max_length"] = aws.StringValue(input.StringAttributeConstraints.MaxLength)
+			}
+
+			value["string_attribute_constraints"] = []map[string]interface{}{subvalue}
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func expandCognitoUserPoolSmsConfiguration(configs []interface{}) *cognitoidentityprovider.SmsConfigurationType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.SmsConfigurationType{
+		SnsCallerArn: aws.String(config["sns_caller_arn"].(string)),
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolSmsConfiguration(config *cognitoidentityprovider.SmsConfigurationType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"external_id":    aws.StringValue(config.ExternalId),
+		"sns_caller_arn": aws.StringValue(config.SnsCallerArn),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolSoftwareTokenMfaConfiguration(configs []interface{}) *cognitoidentityprovider.SoftwareTokenMfaConfigType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.SoftwareTokenMfaConfigType{
+		Enabled: aws.Bool(config["enabled"].(bool)),
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolSoftwareTokenMfaConfiguration(config *cognitoidentityprovider.SoftwareTokenMfaConfigType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"enabled": aws.BoolValue(config.Enabled),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolUserPoolAddOns(configs []interface{}) *cognitoidentityprovider.UserPoolAddOnsType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.UserPoolAddOnsType{
+		AdvancedSecurityMode: aws.String(config["advanced_security_mode"].(string)),
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolUserPoolAddOns(config *cognitoidentityprovider.UserPoolAddOnsType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"advanced_security_mode": aws.StringValue(config.AdvancedSecurityMode),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolVerificationMessageTemplate(configs []interface{}) *cognitoidentityprovider.VerificationMessageTemplateType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.VerificationMessageTemplateType{}
+
+	if v, ok := config["default_email_option"]; ok && v.(string) != "" {
+		configs2.DefaultEmailOption = aws.String(v.(string))
+	}
+
+	if v, ok := config["email_message"]; ok && v.(string) != "" {
+		configs2.EmailMessage = aws.String(v.(string))
+	}
+
+	if v, ok := config["email_message_by_link"]; ok && v.(string) != "" {
+		configs2.EmailMessageByLink = aws.String(v.(string))
+	}
+
+	if v, ok := config["email_subject"]; ok && v.(string) != "" {
+		configs2.EmailSubject = aws.String(v.(string))
+	}
+
+	if v, ok := config["email_subject_by_link"]; ok && v.(string) != "" {
+		configs2.EmailSubjectByLink = aws.String(v.(string))
+	}
+
+	if v, ok := config["sms_message"]; ok && v.(string) != "" {
+		configs2.SmsMessage = aws.String(v.(string))
+	}
+
+	return configs2
+}
+
+func flattenCognitoUserPoolVerificationMessageTemplate(config *cognitoidentityprovider.VerificationMessageTemplateType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"default_email_option":  aws.StringValue(config.DefaultEmailOption),
+		"email_message":         aws.StringValue(config.EmailMessage),
+		"email_message_by_link": aws.StringValue(config.EmailMessageByLink),
+		"email_subject":         aws.StringValue(config.EmailSubject),
+		"email_subject_by_link": aws.StringValue(config.EmailSubjectByLink),
+		"sms_message":           aws.StringValue(config.SmsMessage),
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolAccountRecoverySetting(configs []interface{}) (*cognitoidentityprovider.AccountRecoverySettingType, error) {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil, nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	configs2 := &cognitoidentityprovider.AccountRecoverySettingType{}
+
+	mechanisms := config["recovery_mechanism"].([]interface{})
+	configs2.RecoveryMechanisms = make([]*cognitoidentityprovider.RecoveryOptionType, len(mechanisms))
+
+	priorities := make(map[int64]bool, len(mechanisms))
+	adminOnly := false
+
+	for i, mechanism := range mechanisms {
+		data := mechanism.(map[string]interface{})
+		name := data["name"].(string)
+		priority := int64(data["priority"].(int))
+
+		if priorities[priority] {
+			return nil, fmt.Errorf("account_recovery_setting: priority %d is used by more than one recovery_mechanism", priority)
+		}
+		priorities[priority] = true
+
+		if name == cognitoidentityprovider.RecoveryOptionNameTypeAdminOnly {
+			adminOnly = true
+		}
+
+		configs2.RecoveryMechanisms[i] = &cognitoidentityprovider.RecoveryOptionType{
+			Name:     aws.String(name),
+			Priority: aws.Int64(priority),
+		}
+	}
+
+	if adminOnly && len(mechanisms) > 1 {
+		return nil, fmt.Errorf("account_recovery_setting: admin_only cannot be combined with other recovery_mechanism values")
+	}
+
+	return configs2, nil
+}
+
+func flattenCognitoUserPoolAccountRecoverySetting(config *cognitoidentityprovider.AccountRecoverySettingType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	mechanisms := make([]map[string]interface{}, 0, len(config.RecoveryMechanisms))
+	for _, mechanism := range config.RecoveryMechanisms {
+		if mechanism == nil {
+			continue
+		}
+		mechanisms = append(mechanisms, map[string]interface{}{
+			"name":     aws.StringValue(mechanism.Name),
+			"priority": aws.Int64Value(mechanism.Priority),
+		})
+	}
+
+	// Cognito does not guarantee the order recovery mechanisms come back in,
+	// so sort by priority to avoid a spurious diff against the configured list.
+	sort.Slice(mechanisms, func(i, j int) bool {
+		return mechanisms[i]["priority"].(int64) < mechanisms[j]["priority"].(int64)
+	})
+
+	settings := map[string]interface{}{
+		"recovery_mechanism": mechanisms,
+	}
+
+	return []map[string]interface{}{settings}
+}
+
+func expandCognitoUserPoolUsernameConfiguration(configs []interface{}) *cognitoidentityprovider.UsernameConfigurationType {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+
+	return &cognitoidentityprovider.UsernameConfigurationType{
+		CaseSensitive: aws.Bool(config["case_sensitive"].(bool)),
+	}
+}
+
+func flattenCognitoUserPoolUsernameConfiguration(config *cognitoidentityprovider.UsernameConfigurationType) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+
+	settings := map[string]interface{}{
+		"case_sensitive": aws.BoolValue(config.CaseSensitive),
+	}
+
+	return []map[string]interface{}{settings}
+}
@@ -0,0 +1,371 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceAwsCognitoUserPoolClient() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCognitoUserPoolClientCreate,
+		Read:   resourceAwsCognitoUserPoolClientRead,
+		Update: resourceAwsCognitoUserPoolClientUpdate,
+		Delete: resourceAwsCognitoUserPoolClientDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"allowed_oauth_flows": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 3,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						cognitoidentityprovider.OAuthFlowTypeCode,
+						cognitoidentityprovider.OAuthFlowTypeImplicit,
+						cognitoidentityprovider.OAuthFlowTypeClientCredentials,
+					}, false),
+				},
+			},
+
+			"allowed_oauth_flows_user_pool_client": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"allowed_oauth_scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 50,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"callback_urls": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 100,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"client_secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"default_redirect_uri": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"explicit_auth_flows": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						cognitoidentityprovider.ExplicitAuthFlowsTypeAdminNoSrpAuth,
+						cognitoidentityprovider.ExplicitAuthFlowsTypeCustomAuthFlowOnly,
+						cognitoidentityprovider.ExplicitAuthFlowsTypeUserPasswordAuth,
+						cognitoidentityprovider.ExplicitAuthFlowsTypeAllowAdminUserPasswordAuth,
+						cognitoidentityprovider.ExplicitAuthFlowsTypeAllowCustomAuth,
+						cognitoidentityprovider.ExplicitAuthFlowsTypeAllowUserPasswordAuth,
+						cognitoidentityprovider.ExplicitAuthFlowsTypeAllowUserSrpAuth,
+						cognitoidentityprovider.ExplicitAuthFlowsTypeAllowRefreshTokenAuth,
+					}, false),
+				},
+			},
+
+			"generate_secret": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"logout_urls": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 100,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+
+			"prevent_user_existence_errors": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					cognitoidentityprovider.PreventUserExistenceErrorTypesLegacy,
+					cognitoidentityprovider.PreventUserExistenceErrorTypesEnabled,
+				}, false),
+			},
+
+			"read_attributes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"refresh_token_validity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntBetween(0, 3650),
+			},
+
+			// supported_identity_providers lets a client opt in to
+			// federating through an aws_cognito_identity_provider (or
+			// the built-in "COGNITO" provider) configured on the same
+			// user pool.
+			"supported_identity_providers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"user_pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"write_attributes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAwsCognitoUserPoolClientCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID := d.Get("user_pool_id").(string)
+
+	params := &cognitoidentityprovider.CreateUserPoolClientInput{
+		ClientName: aws.String(d.Get("name").(string)),
+		UserPoolId: aws.String(userPoolID),
+	}
+
+	if v, ok := d.GetOk("allowed_oauth_flows"); ok {
+		params.AllowedOAuthFlows = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOkExists("allowed_oauth_flows_user_pool_client"); ok {
+		params.AllowedOAuthFlowsUserPoolClient = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("allowed_oauth_scopes"); ok {
+		params.AllowedOAuthScopes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("callback_urls"); ok {
+		params.CallbackURLs = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("default_redirect_uri"); ok {
+		params.DefaultRedirectURI = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("explicit_auth_flows"); ok {
+		params.ExplicitAuthFlows = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOkExists("generate_secret"); ok {
+		params.GenerateSecret = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("logout_urls"); ok {
+		params.LogoutURLs = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("prevent_user_existence_errors"); ok {
+		params.PreventUserExistenceErrors = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("read_attributes"); ok {
+		params.ReadAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("refresh_token_validity"); ok {
+		params.RefreshTokenValidity = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("supported_identity_providers"); ok {
+		params.SupportedIdentityProviders = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("write_attributes"); ok {
+		params.WriteAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	log.Printf("[DEBUG] Creating Cognito User Pool Client: %s", params)
+
+	resp, err := conn.CreateUserPoolClient(params)
+	if err != nil {
+		return fmt.Errorf("Error creating Cognito User Pool Client: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", userPoolID, aws.StringValue(resp.UserPoolClient.ClientId)))
+
+	return resourceAwsCognitoUserPoolClientRead(d, meta)
+}
+
+func resourceAwsCognitoUserPoolClientRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, clientID, err := decodeCognitoUserPoolClientID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := conn.DescribeUserPoolClient(&cognitoidentityprovider.DescribeUserPoolClientInput{
+		ClientId:   aws.String(clientID),
+		UserPoolId: aws.String(userPoolID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			log.Printf("[WARN] Cognito User Pool Client %s is already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	client := resp.UserPoolClient
+
+	d.Set("allowed_oauth_flows", aws.StringValueSlice(client.AllowedOAuthFlows))
+	d.Set("allowed_oauth_flows_user_pool_client", client.AllowedOAuthFlowsUserPoolClient)
+	d.Set("allowed_oauth_scopes", aws.StringValueSlice(client.AllowedOAuthScopes))
+	d.Set("callback_urls", aws.StringValueSlice(client.CallbackURLs))
+	d.Set("client_secret", client.ClientSecret)
+	d.Set("default_redirect_uri", client.DefaultRedirectURI)
+	d.Set("explicit_auth_flows", aws.StringValueSlice(client.ExplicitAuthFlows))
+	d.Set("logout_urls", aws.StringValueSlice(client.LogoutURLs))
+	d.Set("name", client.ClientName)
+	d.Set("prevent_user_existence_errors", client.PreventUserExistenceErrors)
+	d.Set("read_attributes", aws.StringValueSlice(client.ReadAttributes))
+	d.Set("refresh_token_validity", client.RefreshTokenValidity)
+	d.Set("supported_identity_providers", aws.StringValueSlice(client.SupportedIdentityProviders))
+	d.Set("user_pool_id", client.UserPoolId)
+	d.Set("write_attributes", aws.StringValueSlice(client.WriteAttributes))
+
+	return nil
+}
+
+func resourceAwsCognitoUserPoolClientUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, clientID, err := decodeCognitoUserPoolClientID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	params := &cognitoidentityprovider.UpdateUserPoolClientInput{
+		ClientId:   aws.String(clientID),
+		ClientName: aws.String(d.Get("name").(string)),
+		UserPoolId: aws.String(userPoolID),
+	}
+
+	if v, ok := d.GetOk("allowed_oauth_flows"); ok {
+		params.AllowedOAuthFlows = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOkExists("allowed_oauth_flows_user_pool_client"); ok {
+		params.AllowedOAuthFlowsUserPoolClient = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("allowed_oauth_scopes"); ok {
+		params.AllowedOAuthScopes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("callback_urls"); ok {
+		params.CallbackURLs = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("default_redirect_uri"); ok {
+		params.DefaultRedirectURI = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("explicit_auth_flows"); ok {
+		params.ExplicitAuthFlows = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("logout_urls"); ok {
+		params.LogoutURLs = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("prevent_user_existence_errors"); ok {
+		params.PreventUserExistenceErrors = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("read_attributes"); ok {
+		params.ReadAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	if v, ok := d.GetOk("refresh_token_validity"); ok {
+		params.RefreshTokenValidity = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("supported_identity_providers"); ok {
+		params.SupportedIdentityProviders = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("write_attributes"); ok {
+		params.WriteAttributes = expandStringList(v.(*schema.Set).List())
+	}
+
+	log.Printf("[DEBUG] Updating Cognito User Pool Client: %s", params)
+
+	_, err = conn.UpdateUserPoolClient(params)
+	if err != nil {
+		return fmt.Errorf("Error updating Cognito User Pool Client: %s", err)
+	}
+
+	return resourceAwsCognitoUserPoolClientRead(d, meta)
+}
+
+func resourceAwsCognitoUserPoolClientDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	userPoolID, clientID, err := decodeCognitoUserPoolClientID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.DeleteUserPoolClient(&cognitoidentityprovider.DeleteUserPoolClientInput{
+		ClientId:   aws.String(clientID),
+		UserPoolId: aws.String(userPoolID),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Cognito User Pool Client: %s", err)
+	}
+
+	return nil
+}
+
+func decodeCognitoUserPoolClientID(id string) (string, string, error) {
+	idParts := strings.SplitN(id, "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("expected ID in format USERPOOLID/CLIENTID, received: %s", id)
+	}
+	return idParts[0], idParts[1], nil
+}
@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccAWSCognitoUserPoolClient_basic(t *testing.T) {
+	userPoolName := acctest.RandomWithPrefix("tf-acc-test-")
+	clientName := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool_client.main"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolClientConfig_basic(userPoolName, clientName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolClientExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", clientName),
+					resource.TestCheckResourceAttr(resourceName, "supported_identity_providers.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "supported_identity_providers.0", "COGNITO"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPoolClient_supportedIdentityProviders(t *testing.T) {
+	userPoolName := acctest.RandomWithPrefix("tf-acc-test-")
+	providerName := acctest.RandomWithPrefix("tf-acc-test-")
+	clientName := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool_client.main"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolClientConfig_supportedIdentityProviders(userPoolName, providerName, clientName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolClientExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "supported_identity_providers.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "supported_identity_providers.0", "COGNITO"),
+					resource.TestCheckResourceAttr(resourceName, "supported_identity_providers.1", providerName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSCognitoUserPoolClientDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).cognitoidpconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_cognito_user_pool_client" {
+			continue
+		}
+
+		userPoolID, clientID, err := decodeCognitoUserPoolClientID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.DescribeUserPoolClient(&cognitoidentityprovider.DescribeUserPoolClientInput{
+			ClientId:   aws.String(clientID),
+			UserPoolId: aws.String(userPoolID),
+		})
+
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAWSCognitoUserPoolClientExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Cognito User Pool Client ID set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).cognitoidpconn
+
+		userPoolID, clientID, err := decodeCognitoUserPoolClientID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.DescribeUserPoolClient(&cognitoidentityprovider.DescribeUserPoolClientInput{
+			ClientId:   aws.String(clientID),
+			UserPoolId: aws.String(userPoolID),
+		})
+
+		return err
+	}
+}
+
+func testAccAWSCognitoUserPoolClientConfig_basic(userPoolName, clientName string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "main" {
+  name = "%s"
+}
+
+resource "aws_cognito_user_pool_client" "main" {
+  name         = "%s"
+  user_pool_id = "${aws_cognito_user_pool.main.id}"
+
+  supported_identity_providers = ["COGNITO"]
+}
+`, userPoolName, clientName)
+}
+
+func testAccAWSCognitoUserPoolClientConfig_supportedIdentityProviders(userPoolName, providerName, clientName string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "main" {
+  name = "%s"
+}
+
+resource "aws_cognito_identity_provider" "main" {
+  user_pool_id  = "${aws_cognito_user_pool.main.id}"
+  provider_name = "%s"
+  provider_type = "Google"
+
+  provider_details = {
+    authorize_scopes = "email"
+    client_id        = "test-client-id"
+    client_secret    = "test-client-secret"
+  }
+
+  attribute_mapping = {
+    email = "email"
+  }
+}
+
+resource "aws_cognito_user_pool_client" "main" {
+  name         = "%s"
+  user_pool_id = "${aws_cognito_user_pool.main.id}"
+
+  supported_identity_providers = ["COGNITO", "${aws_cognito_identity_provider.main.provider_name}"]
+}
+`, userPoolName, providerName, clientName)
+}
@@ -0,0 +1,242 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceAwsCognitoUserPoolDomain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsCognitoUserPoolDomainCreate,
+		Read:   resourceAwsCognitoUserPoolDomainRead,
+		Update: resourceAwsCognitoUserPoolDomainUpdate,
+		Delete: resourceAwsCognitoUserPoolDomainDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"aws_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateArn,
+			},
+
+			"cloudfront_distribution_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`),
+					"must be lowercase alphanumeric characters and hyphens",
+				),
+			},
+
+			"s3_bucket": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"user_pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsCognitoUserPoolDomainCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	domain := d.Get("domain").(string)
+
+	params := &cognitoidentityprovider.CreateUserPoolDomainInput{
+		Domain:     aws.String(domain),
+		UserPoolId: aws.String(d.Get("user_pool_id").(string)),
+	}
+
+	if v, ok := d.GetOk("certificate_arn"); ok {
+		params.CustomDomainConfig = &cognitoidentityprovider.CustomDomainConfigType{
+			CertificateArn: aws.String(v.(string)),
+		}
+	}
+
+	log.Printf("[DEBUG] Creating Cognito User Pool Domain: %s", params)
+
+	_, err := conn.CreateUserPoolDomain(params)
+	if err != nil {
+		return fmt.Errorf("Error creating Cognito User Pool Domain: %s", err)
+	}
+
+	d.SetId(domain)
+
+	if err := resourceAwsCognitoUserPoolDomainWaitUntilActive(conn, domain, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceAwsCognitoUserPoolDomainRead(d, meta)
+}
+
+func resourceAwsCognitoUserPoolDomainRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	resp, err := conn.DescribeUserPoolDomain(&cognitoidentityprovider.DescribeUserPoolDomainInput{
+		Domain: aws.String(d.Id()),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			log.Printf("[WARN] Cognito User Pool Domain %s is already gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	desc := resp.DomainDescription
+	if desc == nil || aws.StringValue(desc.Status) == "" {
+		log.Printf("[WARN] Cognito User Pool Domain %s is already gone", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("domain", d.Id())
+	d.Set("user_pool_id", desc.UserPoolId)
+	d.Set("aws_account_id", desc.AWSAccountId)
+	d.Set("cloudfront_distribution_arn", desc.CloudFrontDistribution)
+	d.Set("s3_bucket", desc.S3Bucket)
+	d.Set("version", desc.Version)
+
+	if desc.CustomDomainConfig != nil {
+		d.Set("certificate_arn", desc.CustomDomainConfig.CertificateArn)
+	}
+
+	return nil
+}
+
+func resourceAwsCognitoUserPoolDomainUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	if d.HasChange("certificate_arn") {
+		params := &cognitoidentityprovider.UpdateUserPoolDomainInput{
+			Domain:     aws.String(d.Id()),
+			UserPoolId: aws.String(d.Get("user_pool_id").(string)),
+			CustomDomainConfig: &cognitoidentityprovider.CustomDomainConfigType{
+				CertificateArn: aws.String(d.Get("certificate_arn").(string)),
+			},
+		}
+
+		log.Printf("[DEBUG] Updating Cognito User Pool Domain: %s", params)
+
+		_, err := conn.UpdateUserPoolDomain(params)
+		if err != nil {
+			return fmt.Errorf("Error updating Cognito User Pool Domain: %s", err)
+		}
+
+		if err := resourceAwsCognitoUserPoolDomainWaitUntilActive(conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsCognitoUserPoolDomainRead(d, meta)
+}
+
+func resourceAwsCognitoUserPoolDomainDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).cognitoidpconn
+
+	_, err := conn.DeleteUserPoolDomain(&cognitoidentityprovider.DeleteUserPoolDomainInput{
+		Domain:     aws.String(d.Id()),
+		UserPoolId: aws.String(d.Get("user_pool_id").(string)),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Cognito User Pool Domain: %s", err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{cognitoidentityprovider.DomainStatusTypeDeleting},
+		Target:  []string{""},
+		Refresh: resourceAwsCognitoUserPoolDomainStateRefreshFunc(conn, d.Id()),
+		Timeout: d.Timeout(schema.TimeoutDelete),
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Cognito User Pool Domain (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsCognitoUserPoolDomainWaitUntilActive polls DescribeUserPoolDomain
+// until the domain leaves CREATING/UPDATING, since CreateUserPoolDomain and
+// UpdateUserPoolDomain both return before the CloudFront distribution backing
+// the domain finishes propagating.
+func resourceAwsCognitoUserPoolDomainWaitUntilActive(conn *cognitoidentityprovider.CognitoIdentityProvider, domain string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			cognitoidentityprovider.DomainStatusTypeCreating,
+			cognitoidentityprovider.DomainStatusTypeUpdating,
+		},
+		Target:  []string{cognitoidentityprovider.DomainStatusTypeActive},
+		Refresh: resourceAwsCognitoUserPoolDomainStateRefreshFunc(conn, domain),
+		Timeout: timeout,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Cognito User Pool Domain (%s) to be active: %s", domain, err)
+	}
+
+	return nil
+}
+
+func resourceAwsCognitoUserPoolDomainStateRefreshFunc(conn *cognitoidentityprovider.CognitoIdentityProvider, domain string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeUserPoolDomain(&cognitoidentityprovider.DescribeUserPoolDomainInput{
+			Domain: aws.String(domain),
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cognitoidentityprovider.ErrCodeResourceNotFoundException {
+				return "", "", nil
+			}
+			return nil, "", err
+		}
+
+		if resp.DomainDescription == nil || aws.StringValue(resp.DomainDescription.Status) == "" {
+			return "", "", nil
+		}
+
+		return resp.DomainDescription, aws.StringValue(resp.DomainDescription.Status), nil
+	}
+}
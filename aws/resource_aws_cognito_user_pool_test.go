@@ -7,6 +7,7 @@ import (
 	"os"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -17,10 +18,126 @@ import (
 )
 
 func init() {
+	resource.AddTestSweepers("aws_cognito_user_pool_domain", &resource.Sweeper{
+		Name: "aws_cognito_user_pool_domain",
+		F:    testSweepCognitoUserPoolDomains,
+	})
+
+	resource.AddTestSweepers("aws_cognito_identity_provider", &resource.Sweeper{
+		Name: "aws_cognito_identity_provider",
+		F:    testSweepCognitoIdentityProviders,
+	})
+
+	resource.AddTestSweepers("aws_cognito_resource_server", &resource.Sweeper{
+		Name: "aws_cognito_resource_server",
+		F:    testSweepCognitoResourceServers,
+	})
+
 	resource.AddTestSweepers("aws_cognito_user_pool", &resource.Sweeper{
 		Name: "aws_cognito_user_pool",
-		F:    testSweepCognitoUserPools,
+		Dependencies: []string{
+			"aws_cognito_user_pool_domain",
+			"aws_cognito_identity_provider",
+			"aws_cognito_resource_server",
+		},
+		F: testSweepCognitoUserPools,
+	})
+}
+
+// cognitoSweeperRetryTimeout bounds the retry-with-backoff used by every
+// Cognito sweeper in this file. The Cognito admin API throttles aggressively
+// (TooManyRequestsException) when a sweep run tears down many pools back to
+// back, so sweeper deletes are retried rather than aborting the whole run.
+const cognitoSweeperRetryTimeout = 2 * time.Minute
+
+func testSweepCognitoUserPoolDomains(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("Error getting client: %s", err)
+	}
+	conn := client.(*AWSClient).cognitoidpconn
+
+	err = listCognitoUserPoolsPages(conn, func(userPool *cognitoidentityprovider.UserPoolDescriptionType) error {
+		poolID := aws.StringValue(userPool.Id)
+
+		describeOutput, err := conn.DescribeUserPool(&cognitoidentityprovider.DescribeUserPoolInput{
+			UserPoolId: userPool.Id,
+		})
+		if err != nil {
+			return fmt.Errorf("Error describing Cognito User Pool %s: %s", poolID, err)
+		}
+
+		domain := aws.StringValue(describeOutput.UserPool.Domain)
+		if domain == "" {
+			return nil
+		}
+
+		log.Printf("[INFO] Deleting Cognito User Pool Domain %s", domain)
+		return resource.Retry(cognitoSweeperRetryTimeout, func() *resource.RetryError {
+			_, err := conn.DeleteUserPoolDomain(&cognitoidentityprovider.DeleteUserPoolDomainInput{
+				Domain:     aws.String(domain),
+				UserPoolId: userPool.Id,
+			})
+			if isAWSErr(err, cognitoidentityprovider.ErrCodeTooManyRequestsException, "") {
+				return resource.RetryableError(err)
+			}
+			if err != nil {
+				return resource.NonRetryableError(fmt.Errorf("Error deleting Cognito User Pool Domain %s: %s", domain, err))
+			}
+			return nil
+		})
 	})
+	if err != nil {
+		if testSweepSkipSweepError(err) {
+			log.Printf("[WARN] Skipping Cognito User Pool Domain sweep for %s: %s", region, err)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func testSweepCognitoIdentityProviders(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("Error getting client: %s", err)
+	}
+	conn := client.(*AWSClient).cognitoidpconn
+
+	err = listCognitoUserPoolsPages(conn, func(userPool *cognitoidentityprovider.UserPoolDescriptionType) error {
+		return deleteCognitoUserPoolIdentityProviders(conn, userPool.Id)
+	})
+	if err != nil {
+		if testSweepSkipSweepError(err) {
+			log.Printf("[WARN] Skipping Cognito Identity Provider sweep for %s: %s", region, err)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func testSweepCognitoResourceServers(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("Error getting client: %s", err)
+	}
+	conn := client.(*AWSClient).cognitoidpconn
+
+	err = listCognitoUserPoolsPages(conn, func(userPool *cognitoidentityprovider.UserPoolDescriptionType) error {
+		return deleteCognitoUserPoolResourceServers(conn, userPool.Id)
+	})
+	if err != nil {
+		if testSweepSkipSweepError(err) {
+			log.Printf("[WARN] Skipping Cognito Resource Server sweep for %s: %s", region, err)
+			return nil
+		}
+		return err
+	}
+
+	return nil
 }
 
 func testSweepCognitoUserPools(region string) error {
@@ -30,6 +147,82 @@ func testSweepCognitoUserPools(region string) error {
 	}
 	conn := client.(*AWSClient).cognitoidpconn
 
+	err = listCognitoUserPoolsPages(conn, func(userPool *cognitoidentityprovider.UserPoolDescriptionType) error {
+		poolID := aws.StringValue(userPool.Id)
+		name := aws.StringValue(userPool.Name)
+
+		// DeleteUserPool fails with InvalidParameterException ("User pool
+		// has a domain associated") if a domain is still attached, so the
+		// domain has to go first. The remaining child resources aren't
+		// strictly blockers, but cleaning them up here too keeps sweep runs
+		// that target only aws_cognito_user_pool from leaking them.
+		describeOutput, err := conn.DescribeUserPool(&cognitoidentityprovider.DescribeUserPoolInput{
+			UserPoolId: userPool.Id,
+		})
+		if err != nil {
+			return fmt.Errorf("Error describing Cognito User Pool %s: %s", poolID, err)
+		}
+
+		if domain := aws.StringValue(describeOutput.UserPool.Domain); domain != "" {
+			log.Printf("[INFO] Deleting Cognito User Pool Domain %s", domain)
+			err := resource.Retry(cognitoSweeperRetryTimeout, func() *resource.RetryError {
+				_, err := conn.DeleteUserPoolDomain(&cognitoidentityprovider.DeleteUserPoolDomainInput{
+					Domain:     aws.String(domain),
+					UserPoolId: userPool.Id,
+				})
+				if isAWSErr(err, cognitoidentityprovider.ErrCodeTooManyRequestsException, "") {
+					return resource.RetryableError(err)
+				}
+				if err != nil {
+					return resource.NonRetryableError(fmt.Errorf("Error deleting Cognito User Pool Domain %s: %s", domain, err))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := deleteCognitoUserPoolIdentityProviders(conn, userPool.Id); err != nil {
+			return err
+		}
+
+		if err := deleteCognitoUserPoolResourceServers(conn, userPool.Id); err != nil {
+			return err
+		}
+
+		if err := deleteCognitoUserPoolClients(conn, userPool.Id); err != nil {
+			return err
+		}
+
+		log.Printf("[INFO] Deleting Cognito User Pool %s", name)
+		return resource.Retry(cognitoSweeperRetryTimeout, func() *resource.RetryError {
+			_, err := conn.DeleteUserPool(&cognitoidentityprovider.DeleteUserPoolInput{
+				UserPoolId: userPool.Id,
+			})
+			if isAWSErr(err, cognitoidentityprovider.ErrCodeTooManyRequestsException, "") {
+				return resource.RetryableError(err)
+			}
+			if err != nil {
+				return resource.NonRetryableError(fmt.Errorf("Error deleting Cognito User Pool %s: %s", name, err))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		if testSweepSkipSweepError(err) {
+			log.Printf("[WARN] Skipping Cognito User Pool sweep for %s: %s", region, err)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// listCognitoUserPoolsPages paginates ListUserPools and invokes fn for every
+// user pool in the region, short-circuiting on the first error.
+func listCognitoUserPoolsPages(conn *cognitoidentityprovider.CognitoIdentityProvider, fn func(*cognitoidentityprovider.UserPoolDescriptionType) error) error {
 	input := &cognitoidentityprovider.ListUserPoolsInput{
 		MaxResults: aws.Int64(int64(50)),
 	}
@@ -37,27 +230,98 @@ func testSweepCognitoUserPools(region string) error {
 	for {
 		output, err := conn.ListUserPools(input)
 		if err != nil {
-			if testSweepSkipSweepError(err) {
-				log.Printf("[WARN] Skipping Cognito User Pool sweep for %s: %s", region, err)
+			return fmt.Errorf("Error retrieving Cognito User Pools: %s", err)
+		}
+
+		for _, userPool := range output.UserPools {
+			if err := fn(userPool); err != nil {
+				return err
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return nil
+}
+
+func deleteCognitoUserPoolIdentityProviders(conn *cognitoidentityprovider.CognitoIdentityProvider, userPoolID *string) error {
+	input := &cognitoidentityprovider.ListIdentityProvidersInput{
+		UserPoolId: userPoolID,
+		MaxResults: aws.Int64(int64(60)),
+	}
+
+	for {
+		output, err := conn.ListIdentityProviders(input)
+		if err != nil {
+			return fmt.Errorf("Error listing Cognito Identity Providers for User Pool %s: %s", aws.StringValue(userPoolID), err)
+		}
+
+		for _, idp := range output.Providers {
+			providerName := idp.ProviderName
+
+			log.Printf("[INFO] Deleting Cognito Identity Provider %s", aws.StringValue(providerName))
+			err := resource.Retry(cognitoSweeperRetryTimeout, func() *resource.RetryError {
+				_, err := conn.DeleteIdentityProvider(&cognitoidentityprovider.DeleteIdentityProviderInput{
+					ProviderName: providerName,
+					UserPoolId:   userPoolID,
+				})
+				if isAWSErr(err, cognitoidentityprovider.ErrCodeTooManyRequestsException, "") {
+					return resource.RetryableError(err)
+				}
+				if err != nil {
+					return resource.NonRetryableError(fmt.Errorf("Error deleting Cognito Identity Provider %s: %s", aws.StringValue(providerName), err))
+				}
 				return nil
+			})
+			if err != nil {
+				return err
 			}
-			return fmt.Errorf("Error retrieving Cognito User Pools: %s", err)
 		}
 
-		if len(output.UserPools) == 0 {
-			log.Print("[DEBUG] No Cognito User Pools to sweep")
-			return nil
+		if output.NextToken == nil {
+			break
 		}
+		input.NextToken = output.NextToken
+	}
 
-		for _, userPool := range output.UserPools {
-			name := aws.StringValue(userPool.Name)
+	return nil
+}
 
-			log.Printf("[INFO] Deleting Cognito User Pool %s", name)
-			_, err := conn.DeleteUserPool(&cognitoidentityprovider.DeleteUserPoolInput{
-				UserPoolId: userPool.Id,
+func deleteCognitoUserPoolResourceServers(conn *cognitoidentityprovider.CognitoIdentityProvider, userPoolID *string) error {
+	input := &cognitoidentityprovider.ListResourceServersInput{
+		UserPoolId: userPoolID,
+		MaxResults: aws.Int64(int64(50)),
+	}
+
+	for {
+		output, err := conn.ListResourceServers(input)
+		if err != nil {
+			return fmt.Errorf("Error listing Cognito Resource Servers for User Pool %s: %s", aws.StringValue(userPoolID), err)
+		}
+
+		for _, server := range output.ResourceServers {
+			identifier := server.Identifier
+
+			log.Printf("[INFO] Deleting Cognito Resource Server %s", aws.StringValue(identifier))
+			err := resource.Retry(cognitoSweeperRetryTimeout, func() *resource.RetryError {
+				_, err := conn.DeleteResourceServer(&cognitoidentityprovider.DeleteResourceServerInput{
+					Identifier: identifier,
+					UserPoolId: userPoolID,
+				})
+				if isAWSErr(err, cognitoidentityprovider.ErrCodeTooManyRequestsException, "") {
+					return resource.RetryableError(err)
+				}
+				if err != nil {
+					return resource.NonRetryableError(fmt.Errorf("Error deleting Cognito Resource Server %s: %s", aws.StringValue(identifier), err))
+				}
+				return nil
 			})
 			if err != nil {
-				return fmt.Errorf("Error deleting Cognito User Pool %s: %s", name, err)
+				return err
 			}
 		}
 
@@ -70,11 +334,68 @@ func testSweepCognitoUserPools(region string) error {
 	return nil
 }
 
+func deleteCognitoUserPoolClients(conn *cognitoidentityprovider.CognitoIdentityProvider, userPoolID *string) error {
+	input := &cognitoidentityprovider.ListUserPoolClientsInput{
+		UserPoolId: userPoolID,
+		MaxResults: aws.Int64(int64(60)),
+	}
+
+	for {
+		output, err := conn.ListUserPoolClients(input)
+		if err != nil {
+			return fmt.Errorf("Error listing Cognito User Pool Clients for User Pool %s: %s", aws.StringValue(userPoolID), err)
+		}
+
+		for _, client := range output.UserPoolClients {
+			clientID := client.ClientId
+
+			log.Printf("[INFO] Deleting Cognito User Pool Client %s", aws.StringValue(clientID))
+			err := resource.Retry(cognitoSweeperRetryTimeout, func() *resource.RetryError {
+				_, err := conn.DeleteUserPoolClient(&cognitoidentityprovider.DeleteUserPoolClientInput{
+					ClientId:   clientID,
+					UserPoolId: userPoolID,
+				})
+				if isAWSErr(err, cognitoidentityprovider.ErrCodeTooManyRequestsException, "") {
+					return resource.RetryableError(err)
+				}
+				if err != nil {
+					return resource.NonRetryableError(fmt.Errorf("Error deleting Cognito User Pool Client %s: %s", aws.StringValue(clientID), err))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return nil
+}
+
+// testAccCognitoParallel runs test as resource.ParallelTest unless
+// TF_ACC_COGNITO_PARALLEL is set to "0" or "false", in which case it falls back
+// to resource.Test. Cognito enforces a low per-region user pool quota, so CI
+// can opt to serialize this suite rather than exhaust it when run alongside
+// other jobs against the same account.
+func testAccCognitoParallel(t *testing.T, c resource.TestCase) {
+	if v := os.Getenv("TF_ACC_COGNITO_PARALLEL"); v == "0" || v == "false" {
+		resource.Test(t, c)
+		return
+	}
+
+	resource.ParallelTest(t, c)
+}
+
 func TestAccAWSCognitoUserPool_basic(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -87,7 +408,7 @@ func TestAccAWSCognitoUserPool_basic(t *testing.T) {
 						regexp.MustCompile(`^arn:aws:cognito-idp:[^:]+:[0-9]{12}:userpool/[\w-]+_[0-9a-zA-Z]+$`)),
 					resource.TestMatchResourceAttr(resourceName, "endpoint",
 						regexp.MustCompile(`^cognito-idp\.[^.]+\.amazonaws.com/[\w-]+_[0-9a-zA-Z]+$`)),
-					resource.TestCheckResourceAttr(resourceName, "name", "terraform-test-pool-"+name),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
 					resource.TestCheckResourceAttrSet(resourceName, "creation_date"),
 					resource.TestCheckResourceAttrSet(resourceName, "last_modified_date"),
 				),
@@ -102,10 +423,10 @@ func TestAccAWSCognitoUserPool_basic(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withAdminCreateUserConfiguration(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -139,10 +460,10 @@ func TestAccAWSCognitoUserPool_withAdminCreateUserConfiguration(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withAdvancedSecurityMode(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -159,6 +480,12 @@ func TestAccAWSCognitoUserPool_withAdvancedSecurityMode(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withAdvancedSecurityMode(name, "AUDIT"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_pool_add_ons.0.advanced_security_mode", "AUDIT"),
+				),
+			},
 			{
 				Config: testAccAWSCognitoUserPoolConfig_withAdvancedSecurityMode(name, "ENFORCED"),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -176,10 +503,10 @@ func TestAccAWSCognitoUserPool_withAdvancedSecurityMode(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withDeviceConfiguration(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -209,14 +536,14 @@ func TestAccAWSCognitoUserPool_withDeviceConfiguration(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withEmailVerificationMessage(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	subject := acctest.RandString(10)
 	updatedSubject := acctest.RandString(10)
 	message := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	upatedMessage := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -246,14 +573,14 @@ func TestAccAWSCognitoUserPool_withEmailVerificationMessage(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withSmsVerificationMessage(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	authenticationMessage := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	updatedAuthenticationMessage := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	verificationMessage := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	upatedVerificationMessage := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -283,7 +610,7 @@ func TestAccAWSCognitoUserPool_withSmsVerificationMessage(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withEmailConfiguration(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	replyTo := fmt.Sprintf("tf-acc-reply-%s@terraformtesting.com", name)
 	resourceName := "aws_cognito_user_pool.test"
 
@@ -292,7 +619,7 @@ func TestAccAWSCognitoUserPool_withEmailConfiguration(t *testing.T) {
 		t.Skip("'TEST_AWS_SES_VERIFIED_EMAIL_ARN' not set, skipping test.")
 	}
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -326,10 +653,10 @@ func TestAccAWSCognitoUserPool_withEmailConfiguration(t *testing.T) {
 // Ensure we can create a User Pool, handling IAM role propagation,
 // taking some time.
 func TestAccAWSCognitoUserPool_withSmsConfiguration(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -353,10 +680,10 @@ func TestAccAWSCognitoUserPool_withSmsConfiguration(t *testing.T) {
 
 // Ensure we can update a User Pool, handling IAM role propagation.
 func TestAccAWSCognitoUserPool_withSmsConfigurationUpdated(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -385,11 +712,38 @@ func TestAccAWSCognitoUserPool_withSmsConfigurationUpdated(t *testing.T) {
 	})
 }
 
+func TestAccAWSCognitoUserPool_withSoftwareTokenMfaConfiguration(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool.test"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withSoftwareTokenMfaConfiguration(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "mfa_configuration", cognitoidentityprovider.UserPoolMfaTypeOn),
+					resource.TestCheckResourceAttr(resourceName, "software_token_mfa_configuration.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "software_token_mfa_configuration.0.enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAWSCognitoUserPool_withTags(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -418,10 +772,10 @@ func TestAccAWSCognitoUserPool_withTags(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withAliasAttributes(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -454,11 +808,133 @@ func TestAccAWSCognitoUserPool_withAliasAttributes(t *testing.T) {
 	})
 }
 
+func TestAccAWSCognitoUserPool_withUsernameAttributes(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool.test"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withUsernameAttributes(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "username_attributes.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "username_attributes.881205744", "email"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withAccountRecoverySetting(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool.test"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withAccountRecoverySetting(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.0.name", "verified_email"),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.0.priority", "1"),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.1.name", "verified_phone_number"),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.1.priority", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withAccountRecoverySettingUpdated(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.0.name", "admin_only"),
+					resource.TestCheckResourceAttr(resourceName, "account_recovery_setting.0.recovery_mechanism.0.priority", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withAccountRecoverySettingDuplicatePriorityFails(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSCognitoUserPoolConfig_withAccountRecoverySettingDuplicatePriority(name),
+				ExpectError: regexp.MustCompile(`priority 1 is used by more than one recovery_mechanism`),
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withAccountRecoverySettingAdminOnlyFails(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSCognitoUserPoolConfig_withAccountRecoverySettingAdminOnlyCombined(name),
+				ExpectError: regexp.MustCompile(`admin_only cannot be combined with other recovery_mechanism values`),
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withUsernameConfiguration(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool.test"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withUsernameConfiguration(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "username_configuration.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "username_configuration.0.case_sensitive", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAWSCognitoUserPool_withPasswordPolicy(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -498,10 +974,10 @@ func TestAccAWSCognitoUserPool_withPasswordPolicy(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_withLambdaConfig(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -548,11 +1024,70 @@ func TestAccAWSCognitoUserPool_withLambdaConfig(t *testing.T) {
 	})
 }
 
+func TestAccAWSCognitoUserPool_withStrictLambdaPermissions(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool.test"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withStrictLambdaPermissions(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "strict_lambda_permissions", "false"),
+				),
+			},
+			{
+				// The Lambda function has no resource policy granting
+				// cognito-idp.amazonaws.com invoke access, so flipping
+				// strict_lambda_permissions on should fail the plan.
+				Config:      testAccAWSCognitoUserPoolConfig_withStrictLambdaPermissions(name, true),
+				ExpectError: regexp.MustCompile(`has no resource policy, so cognito-idp\.amazonaws\.com cannot invoke it`),
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withCustomSenderLambdaConfig(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+	resourceName := "aws_cognito_user_pool.test"
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withCustomSenderLambdaConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAWSCognitoUserPoolExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "lambda_config.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "lambda_config.0.kms_key_id"),
+					resource.TestCheckResourceAttr(resourceName, "lambda_config.0.custom_email_sender.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "lambda_config.0.custom_email_sender.0.lambda_version", "V1_0"),
+					resource.TestCheckResourceAttrSet(resourceName, "lambda_config.0.custom_email_sender.0.lambda_arn"),
+					resource.TestCheckResourceAttr(resourceName, "lambda_config.0.custom_sms_sender.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "lambda_config.0.custom_sms_sender.0.lambda_version", "V1_0"),
+					resource.TestCheckResourceAttrSet(resourceName, "lambda_config.0.custom_sms_sender.0.lambda_arn"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAWSCognitoUserPool_withSchemaAttributes(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -588,16 +1123,21 @@ func TestAccAWSCognitoUserPool_withSchemaAttributes(t *testing.T) {
 			{
 				Config: testAccAWSCognitoUserPoolConfig_withSchemaAttributesUpdated(name),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "schema.#", "3"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.attribute_data_type", "String"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.developer_only_attribute", "false"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.mutable", "false"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.name", "email"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.number_attribute_constraints.#", "0"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.required", "true"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.string_attribute_constraints.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.string_attribute_constraints.0.min_length", "7"),
-					resource.TestCheckResourceAttr(resourceName, "schema.2078884933.string_attribute_constraints.0.max_length", "15"),
+					resource.TestCheckResourceAttr(resourceName, "schema.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.attribute_data_type", "String"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.developer_only_attribute", "false"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.mutable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.name", "email"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.number_attribute_constraints.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.required", "true"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.string_attribute_constraints.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.string_attribute_constraints.0.min_length", "5"),
+					resource.TestCheckResourceAttr(resourceName, "schema.145451252.string_attribute_constraints.0.max_length", "10"),
+					resource.TestCheckResourceAttr(resourceName, "schema.770828826.attribute_data_type", "Boolean"),
+					resource.TestCheckResourceAttr(resourceName, "schema.770828826.developer_only_attribute", "true"),
+					resource.TestCheckResourceAttr(resourceName, "schema.770828826.mutable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "schema.770828826.name", "mybool"),
+					resource.TestCheckResourceAttr(resourceName, "schema.770828826.required", "false"),
 					resource.TestCheckResourceAttr(resourceName, "schema.2718111653.attribute_data_type", "Number"),
 					resource.TestCheckResourceAttr(resourceName, "schema.2718111653.developer_only_attribute", "true"),
 					resource.TestCheckResourceAttr(resourceName, "schema.2718111653.mutable", "true"),
@@ -619,19 +1159,76 @@ func TestAccAWSCognitoUserPool_withSchemaAttributes(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withSchemaAttributesRemovalFails(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withSchemaAttributes(name),
+			},
+			{
+				Config:      testAccAWSCognitoUserPoolConfig_withSchemaAttributesRemoved(name),
+				ExpectError: regexp.MustCompile(`schema is add-only`),
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withSchemaAttributesMutableFlipFails(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withSchemaAttributes(name),
+			},
+			{
+				Config:      testAccAWSCognitoUserPoolConfig_withSchemaAttributesMutableFlipped(name),
+				ExpectError: regexp.MustCompile(`cannot change mutable on existing attribute`),
+			},
+		},
+	})
+}
+
+func TestAccAWSCognitoUserPool_withSchemaAttributesConstraintsShrinkFails(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test-")
+
+	testAccCognitoParallel(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSCognitoUserPoolConfig_withSchemaAttributes(name),
+			},
+			{
+				Config:      testAccAWSCognitoUserPoolConfig_withSchemaAttributesConstraintsShrunk(name),
+				ExpectError: regexp.MustCompile(`Cognito does not support shrinking an attribute's allowed value range`),
 			},
 		},
 	})
 }
 
 func TestAccAWSCognitoUserPool_withVerificationMessageTemplate(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -681,14 +1278,14 @@ func TestAccAWSCognitoUserPool_withVerificationMessageTemplate(t *testing.T) {
 }
 
 func TestAccAWSCognitoUserPool_update(t *testing.T) {
-	name := acctest.RandString(5)
+	name := acctest.RandomWithPrefix("tf-acc-test-")
 	optionalMfa := "OPTIONAL"
 	offMfa := "OFF"
 	authenticationMessage := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	updatedAuthenticationMessage := fmt.Sprintf("%s {####}", acctest.RandString(10))
 	resourceName := "aws_cognito_user_pool.test"
 
-	resource.ParallelTest(t, resource.TestCase{
+	testAccCognitoParallel(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSCognitoIdentityProvider(t) },
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckAWSCognitoUserPoolDestroy,
@@ -840,7 +1437,7 @@ func testAccPreCheckAWSCognitoIdentityProvider(t *testing.T) {
 func testAccAWSCognitoUserPoolConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 }
 `, name)
 }
@@ -848,7 +1445,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withAdminCreateUserConfiguration(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   admin_create_user_config {
     allow_admin_create_user_only = true
@@ -866,7 +1463,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withAdminCreateUserConfigurationUpdated(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   admin_create_user_config {
     allow_admin_create_user_only = false
@@ -884,7 +1481,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withAdvancedSecurityMode(name string, mode string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   user_pool_add_ons {
     advanced_security_mode = "%s"
@@ -896,7 +1493,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withDeviceConfiguration(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   device_configuration {
     challenge_required_on_new_device      = true
@@ -909,7 +1506,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withDeviceConfigurationUpdated(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   device_configuration {
     challenge_required_on_new_device      = false
@@ -922,7 +1519,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withEmailVerificationMessage(name, subject, message string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name                       = "terraform-test-pool-%s"
+  name                       = "%s"
   email_verification_subject = "%s"
   email_verification_message = "%s"
 
@@ -936,17 +1533,30 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withSmsVerificationMessage(name, authenticationMessage, verificationMessage string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name                       = "terraform-test-pool-%s"
+  name                       = "%s"
   sms_authentication_message = "%s"
   sms_verification_message   = "%s"
 }
 `, name, authenticationMessage, verificationMessage)
 }
 
+func testAccAWSCognitoUserPoolConfig_withSoftwareTokenMfaConfiguration(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name              = "%s"
+  mfa_configuration = "ON"
+
+  software_token_mfa_configuration {
+    enabled = true
+  }
+}
+`, name)
+}
+
 func testAccAWSCognitoUserPoolConfig_withTags(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   tags = {
     "Name" = "Foo"
@@ -958,7 +1568,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withEmailConfiguration(name, email, arn, account string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-    name = "terraform-test-pool-%[1]s"
+    name = "%[1]s"
 
 
     email_configuration {
@@ -970,6 +1580,8 @@ resource "aws_cognito_user_pool" "test" {
   }`, name, email, arn, account)
 }
 
+// name is reused for the IAM role and user pool so that parallel runs of
+// this suite never collide on a global-namespace resource.
 func testAccAWSCognitoUserPoolConfig_withSmsConfiguration(name string) string {
 	return fmt.Sprintf(`
 data "aws_caller_identity" "current" {}
@@ -1023,7 +1635,7 @@ EOF
 }
 
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%[1]s"
+  name = "%[1]s"
 
   sms_configuration {
     external_id    = "${data.aws_caller_identity.current.account_id}"
@@ -1036,7 +1648,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withTagsUpdated(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   tags = {
     "Name"    = "FooBar"
@@ -1049,7 +1661,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withAliasAttributes(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   alias_attributes = ["preferred_username"]
 }
@@ -1059,7 +1671,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withAliasAttributesUpdated(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   alias_attributes         = ["email", "preferred_username"]
   auto_verified_attributes = ["email"]
@@ -1067,10 +1679,107 @@ resource "aws_cognito_user_pool" "test" {
 `, name)
 }
 
+func testAccAWSCognitoUserPoolConfig_withUsernameAttributes(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%s"
+
+  username_attributes = ["email"]
+}
+`, name)
+}
+
+func testAccAWSCognitoUserPoolConfig_withAccountRecoverySetting(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%s"
+
+  account_recovery_setting {
+    recovery_mechanism {
+      name     = "verified_email"
+      priority = 1
+    }
+
+    recovery_mechanism {
+      name     = "verified_phone_number"
+      priority = 2
+    }
+  }
+}
+`, name)
+}
+
+func testAccAWSCognitoUserPoolConfig_withAccountRecoverySettingUpdated(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%s"
+
+  account_recovery_setting {
+    recovery_mechanism {
+      name     = "admin_only"
+      priority = 1
+    }
+  }
+}
+`, name)
+}
+
+func testAccAWSCognitoUserPoolConfig_withAccountRecoverySettingDuplicatePriority(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%s"
+
+  account_recovery_setting {
+    recovery_mechanism {
+      name     = "verified_email"
+      priority = 1
+    }
+
+    recovery_mechanism {
+      name     = "verified_phone_number"
+      priority = 1
+    }
+  }
+}
+`, name)
+}
+
+func testAccAWSCognitoUserPoolConfig_withAccountRecoverySettingAdminOnlyCombined(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%s"
+
+  account_recovery_setting {
+    recovery_mechanism {
+      name     = "admin_only"
+      priority = 1
+    }
+
+    recovery_mechanism {
+      name     = "verified_email"
+      priority = 2
+    }
+  }
+}
+`, name)
+}
+
+func testAccAWSCognitoUserPoolConfig_withUsernameConfiguration(name string, caseSensitive bool) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%s"
+
+  username_configuration {
+    case_sensitive = %t
+  }
+}
+`, name, caseSensitive)
+}
+
 func testAccAWSCognitoUserPoolConfig_withPasswordPolicy(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   password_policy {
     minimum_length                   = 7
@@ -1087,7 +1796,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withPasswordPolicyUpdated(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   password_policy {
     minimum_length                   = 9
@@ -1101,6 +1810,8 @@ resource "aws_cognito_user_pool" "test" {
 `, name)
 }
 
+// name is reused for the IAM role, Lambda function, and user pool so that
+// parallel runs of this suite never collide on a global-namespace resource.
 func testAccAWSCognitoUserPoolConfig_withLambdaConfig(name string) string {
 	return fmt.Sprintf(`
 resource "aws_iam_role" "test" {
@@ -1207,6 +1918,110 @@ resource "aws_cognito_user_pool" "test" {
 `, name)
 }
 
+func testAccAWSCognitoUserPoolConfig_withStrictLambdaPermissions(name string, strict bool) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = "%[1]s"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "lambda.amazonaws.com"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_lambda_function" "test" {
+  filename      = "test-fixtures/lambdatest.zip"
+  function_name = "%[1]s"
+  role          = "${aws_iam_role.test.arn}"
+  handler       = "exports.example"
+  runtime       = "nodejs8.10"
+}
+
+resource "aws_cognito_user_pool" "test" {
+  name                      = "%[1]s"
+  strict_lambda_permissions = %[2]t
+
+  lambda_config {
+    pre_sign_up = "${aws_lambda_function.test.arn}"
+  }
+}
+`, name, strict)
+}
+
+func testAccAWSCognitoUserPoolConfig_withCustomSenderLambdaConfig(name string) string {
+	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+  description             = "tf-acc-test-cognito-%[1]s"
+  deletion_window_in_days = 7
+}
+
+resource "aws_iam_role" "test" {
+  name = "%[1]s"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "lambda.amazonaws.com"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_lambda_function" "email_sender" {
+  filename      = "test-fixtures/lambdatest.zip"
+  function_name = "%[1]s_email_sender"
+  role          = "${aws_iam_role.test.arn}"
+  handler       = "exports.example"
+  runtime       = "nodejs8.10"
+}
+
+resource "aws_lambda_function" "sms_sender" {
+  filename      = "test-fixtures/lambdatest.zip"
+  function_name = "%[1]s_sms_sender"
+  role          = "${aws_iam_role.test.arn}"
+  handler       = "exports.example"
+  runtime       = "nodejs8.10"
+}
+
+resource "aws_cognito_user_pool" "test" {
+  name = "%[1]s"
+
+  lambda_config {
+    kms_key_id = "${aws_kms_key.test.arn}"
+
+    custom_email_sender {
+      lambda_arn     = "${aws_lambda_function.email_sender.arn}"
+      lambda_version = "V1_0"
+    }
+
+    custom_sms_sender {
+      lambda_arn     = "${aws_lambda_function.sms_sender.arn}"
+      lambda_version = "V1_0"
+    }
+  }
+}
+`, name)
+}
+
 func testAccAWSCognitoUserPoolConfig_withSchemaAttributes(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
@@ -1236,6 +2051,11 @@ resource "aws_cognito_user_pool" "test" {
 `, name)
 }
 
+// testAccAWSCognitoUserPoolConfig_withSchemaAttributesUpdated only adds new
+// schema attributes on top of testAccAWSCognitoUserPoolConfig_withSchemaAttributes;
+// existing attributes are left byte-for-byte identical. Cognito's schema set
+// is add-only, so removing "mybool" or shrinking email's
+// string_attribute_constraints here would now be rejected at plan time.
 func testAccAWSCognitoUserPoolConfig_withSchemaAttributesUpdated(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
@@ -1249,11 +2069,19 @@ resource "aws_cognito_user_pool" "test" {
     required                 = true
 
     string_attribute_constraints {
-      min_length = 7
-      max_length = 15
+      min_length = 5
+      max_length = 10
     }
   }
 
+  schema {
+    attribute_data_type      = "Boolean"
+    developer_only_attribute = true
+    mutable                  = false
+    name                     = "mybool"
+    required                 = false
+  }
+
   schema {
     attribute_data_type      = "Number"
     developer_only_attribute = true
@@ -1283,10 +2111,99 @@ resource "aws_cognito_user_pool" "test" {
 `, name)
 }
 
+// testAccAWSCognitoUserPoolConfig_withSchemaAttributesRemoved drops the
+// "mybool" attribute present in testAccAWSCognitoUserPoolConfig_withSchemaAttributes,
+// which the add-only schema validation should reject at plan time.
+func testAccAWSCognitoUserPoolConfig_withSchemaAttributesRemoved(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%[1]s"
+
+  schema {
+    attribute_data_type      = "String"
+    developer_only_attribute = false
+    mutable                  = false
+    name                     = "email"
+    required                 = true
+
+    string_attribute_constraints {
+      min_length = 5
+      max_length = 10
+    }
+  }
+}
+`, name)
+}
+
+// testAccAWSCognitoUserPoolConfig_withSchemaAttributesMutableFlipped flips
+// "email"'s mutable flag from testAccAWSCognitoUserPoolConfig_withSchemaAttributes,
+// which should be rejected at plan time.
+func testAccAWSCognitoUserPoolConfig_withSchemaAttributesMutableFlipped(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%[1]s"
+
+  schema {
+    attribute_data_type      = "String"
+    developer_only_attribute = false
+    mutable                  = true
+    name                     = "email"
+    required                 = true
+
+    string_attribute_constraints {
+      min_length = 5
+      max_length = 10
+    }
+  }
+
+  schema {
+    attribute_data_type      = "Boolean"
+    developer_only_attribute = true
+    mutable                  = false
+    name                     = "mybool"
+    required                 = false
+  }
+}
+`, name)
+}
+
+// testAccAWSCognitoUserPoolConfig_withSchemaAttributesConstraintsShrunk
+// narrows "email"'s string_attribute_constraints from
+// testAccAWSCognitoUserPoolConfig_withSchemaAttributes, which should be
+// rejected at plan time.
+func testAccAWSCognitoUserPoolConfig_withSchemaAttributesConstraintsShrunk(name string) string {
+	return fmt.Sprintf(`
+resource "aws_cognito_user_pool" "test" {
+  name = "%[1]s"
+
+  schema {
+    attribute_data_type      = "String"
+    developer_only_attribute = false
+    mutable                  = false
+    name                     = "email"
+    required                 = true
+
+    string_attribute_constraints {
+      min_length = 6
+      max_length = 9
+    }
+  }
+
+  schema {
+    attribute_data_type      = "Boolean"
+    developer_only_attribute = true
+    mutable                  = false
+    name                     = "mybool"
+    required                 = false
+  }
+}
+`, name)
+}
+
 func testAccAWSCognitoUserPoolConfig_withVerificationMessageTemplate(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   # Setting Verification template attributes like EmailMessage, EmailSubject or SmsMessage
   # will implicitly set EmailVerificationMessage, EmailVerificationSubject and SmsVerificationMessage
@@ -1306,7 +2223,7 @@ resource "aws_cognito_user_pool" "test" {
 func testAccAWSCognitoUserPoolConfig_withVerificationMessageTemplate_DefaultEmailOption(name string) string {
 	return fmt.Sprintf(`
 resource "aws_cognito_user_pool" "test" {
-  name = "terraform-test-pool-%s"
+  name = "%s"
 
   email_verification_message = "{####} Baz"
   email_verification_subject = "BazBaz {####}"
@@ -1372,7 +2289,7 @@ EOF
 }
 
 resource "aws_cognito_user_pool" "test" {
-  name                     = "terraform-test-pool-%s"
+  name                     = "%s"
   auto_verified_attributes = ["email"]
   mfa_configuration        = "%s"
 